@@ -0,0 +1,93 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// ActivationSecretLen is the length in bytes of an account activation secret
+const ActivationSecretLen = 20
+
+// AccountActivation models the tezos activate_account operation type, which
+// activates a fundraiser account
+type AccountActivation struct {
+	// PublicKeyHash is the tz1 address of the fundraiser account
+	PublicKeyHash ContractID
+	Secret        [ActivationSecretLen]byte
+}
+
+func (a *AccountActivation) String() string {
+	return fmt.Sprintf("%#v", a)
+}
+
+// GetTag implements OperationContents
+func (a *AccountActivation) GetTag() ContentsTag {
+	return ContentsTagActivateAccount
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (a *AccountActivation) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(a.GetTag()))
+
+	// public key hash. Fundraiser accounts are always ed25519, so the hash is untagged.
+	b58prefix, pubKeyHash, err := Base58CheckDecode(string(a.PublicKeyHash))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write public key hash: %w", err)
+	}
+	if b58prefix != PrefixEd25519PublicKeyHash {
+		return nil, xerrors.Errorf("activated account %s must be a tz1 address", a.PublicKeyHash)
+	}
+	buf.Write(pubKeyHash)
+
+	// secret
+	buf.Write(a.Secret[:])
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (a *AccountActivation) UnmarshalBinary(data []byte) error {
+	_, err := a.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the account activation at the start of data and returns the number
+// of bytes it occupies
+func (a *AccountActivation) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagActivateAccount {
+		return 0, xerrors.Errorf("invalid tag for account activation. Expected %d, saw %d", ContentsTagActivateAccount, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// public key hash
+	pubKeyHash, err := Base58CheckEncode(PrefixEd25519PublicKeyHash, dataPtr[:PubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal public key hash: %w", err)
+	}
+	a.PublicKeyHash = ContractID(pubKeyHash)
+	dataPtr = dataPtr[PubKeyHashLen:]
+
+	// secret
+	copy(a.Secret[:], dataPtr[:ActivationSecretLen])
+	dataPtr = dataPtr[ActivationSecretLen:]
+
+	return len(data) - len(dataPtr), nil
+}
@@ -0,0 +1,36 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const accountActivationHex = "04" + "02298c03ed7d454a101eb7022bc95f7e5f41ac78" + "41f98b15efc63fa893d61d7d6eee4a2ce9427ac4"
+
+func TestEncodeAccountActivation(t *testing.T) {
+	require := require.New(t)
+	activation := &tezosprotocol.AccountActivation{PublicKeyHash: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}
+	copy(activation.Secret[:], fromHex("41f98b15efc63fa893d61d7d6eee4a2ce9427ac4"))
+	encodedBytes, err := activation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(accountActivationHex, hex.EncodeToString(encodedBytes))
+
+	activation.PublicKeyHash = "KT1WfRb2j1YPot5PR1CRPKowiteVmKGaA5NA"
+	_, err = activation.MarshalBinary()
+	require.Error(err)
+}
+
+func TestDecodeAccountActivation(t *testing.T) {
+	require := require.New(t)
+	branch := fromHex("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f")
+	operation := tezosprotocol.Operation{}
+	require.NoError(operation.UnmarshalBinary(append(branch, fromHex(accountActivationHex)...)))
+	require.Len(operation.Contents, 1)
+	activation, ok := operation.Contents[0].(*tezosprotocol.AccountActivation)
+	require.True(ok)
+	require.Equal(tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"), activation.PublicKeyHash)
+	require.Equal("41f98b15efc63fa893d61d7d6eee4a2ce9427ac4", hex.EncodeToString(activation.Secret[:]))
+}
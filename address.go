@@ -0,0 +1,87 @@
+package tezosprotocol
+
+import (
+	"golang.org/x/xerrors"
+)
+
+// Address is a tezos account identifier that can be serialized in operations
+type Address interface {
+	MarshalBinary() ([]byte, error)
+	AccountType() (AccountType, error)
+	String() string
+}
+
+var (
+	_ Address = ContractID("")
+	_ Address = PublicKeyHash{}
+)
+
+// PublicKeyHash models $public_key_hash: the hash of an implicit account's public
+// key, preceded by a tag for the key's curve. It is how implicit accounts are
+// serialized where only they are allowed, such as operation sources.
+type PublicKeyHash [TaggedPubKeyHashLen]byte
+
+// NewPublicKeyHash returns the public key hash of an implicit account. It errors if
+// contractID is not an implicit account.
+func NewPublicKeyHash(contractID ContractID) (PublicKeyHash, error) {
+	var p PublicKeyHash
+	encoded, err := contractID.EncodePubKeyHash()
+	if err != nil {
+		return p, err
+	}
+	copy(p[:], encoded)
+	return p, nil
+}
+
+// ContractID returns the tz1, tz2 or tz3 address of the public key hash
+func (p PublicKeyHash) ContractID() (ContractID, error) {
+	var prefix Base58CheckPrefix
+	switch PubKeyHashTag(p[0]) {
+	case PubKeyHashTagEd25519:
+		prefix = PrefixEd25519PublicKeyHash
+	case PubKeyHashTagSecp256k1:
+		prefix = PrefixSecp256k1PublicKeyHash
+	case PubKeyHashTagP256:
+		prefix = PrefixP256PublicKeyHash
+	default:
+		return "", xerrors.Errorf("unexpected pub_key_hash tag %d", p[0])
+	}
+	encoded, err := Base58CheckEncode(prefix, p[1:])
+	return ContractID(encoded), err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (p PublicKeyHash) MarshalBinary() ([]byte, error) {
+	if _, err := p.ContractID(); err != nil {
+		return nil, err
+	}
+	return append([]byte{}, p[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (p *PublicKeyHash) UnmarshalBinary(data []byte) error {
+	if len(data) != TaggedPubKeyHashLen {
+		return xerrors.Errorf("expected %d bytes for tagged public key hash; received %d: %w", TaggedPubKeyHashLen, len(data), ErrUnexpectedLength)
+	}
+	var decoded PublicKeyHash
+	copy(decoded[:], data)
+	if _, err := decoded.ContractID(); err != nil {
+		return err
+	}
+	*p = decoded
+	return nil
+}
+
+// AccountType implements Address. Public key hashes are always implicit accounts.
+func (p PublicKeyHash) AccountType() (AccountType, error) {
+	return AccountTypeImplicit, nil
+}
+
+// String implements fmt.Stringer, returning the account's address
+func (p PublicKeyHash) String() string {
+	contractID, err := p.ContractID()
+	if err != nil {
+		return "<invalid public key hash>"
+	}
+	return string(contractID)
+}
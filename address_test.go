@@ -0,0 +1,43 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeyHashType(t *testing.T) {
+	require := require.New(t)
+	contractID := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	publicKeyHash, err := tezosprotocol.NewPublicKeyHash(contractID)
+	require.NoError(err)
+	encoded, err := publicKeyHash.MarshalBinary()
+	require.NoError(err)
+	require.Equal("0002298c03ed7d454a101eb7022bc95f7e5f41ac78", hex.EncodeToString(encoded))
+	require.Equal(string(contractID), publicKeyHash.String())
+
+	var decoded tezosprotocol.PublicKeyHash
+	require.NoError(decoded.UnmarshalBinary(encoded))
+	roundTripped, err := decoded.ContractID()
+	require.NoError(err)
+	require.Equal(contractID, roundTripped)
+
+	// addresses share an interface
+	for _, address := range []tezosprotocol.Address{contractID, publicKeyHash} {
+		accountType, err := address.AccountType()
+		require.NoError(err)
+		require.Equal(tezosprotocol.AccountTypeImplicit, accountType)
+		require.Equal(string(contractID), address.String())
+	}
+
+	// originated accounts have no public key hash
+	_, err = tezosprotocol.NewPublicKeyHash(tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq"))
+	require.Error(err)
+
+	// bad tag and bad length
+	encoded[0] = 0x09
+	require.Error(decoded.UnmarshalBinary(encoded))
+	require.Error(decoded.UnmarshalBinary(encoded[:20]))
+}
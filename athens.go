@@ -0,0 +1,276 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// athensContentsTags maps the current tags to those of protocol 004
+var athensContentsTags = map[ContentsTag]byte{
+	ContentsTagEndorsement:          0,
+	ContentsTagSeedNonceRevelation:  1,
+	ContentsTagDoubleBakingEvidence: 3,
+	ContentsTagActivateAccount:      4,
+	ContentsTagProposals:            5,
+	ContentsTagRevelation:           7,
+	ContentsTagTransaction:          8,
+	ContentsTagOrigination:          9,
+	ContentsTagDelegation:           10,
+}
+
+// marshalAthensContents encodes content in the layout of protocol 004. Consensus,
+// anonymous and governance contents are laid out as today under different tags.
+// Manager operations differ: their source is a 22 byte $contract_id, since
+// originated accounts could be managers, and transactions have no entrypoint.
+func marshalAthensContents(content OperationContents) ([]byte, error) {
+	tag, ok := athensContentsTags[content.GetTag()]
+	if !ok {
+		return nil, xerrors.Errorf("content tag %d has no equivalent in protocol %s", content.GetTag(), ProtocolAthens)
+	}
+	switch c := content.(type) {
+	case *Endorsement, *SeedNonceRevelation, *DoubleBakingEvidence, *AccountActivation, *Proposals:
+		contentBytes, err := content.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		contentBytes[0] = tag
+		return contentBytes, nil
+	case *Revelation:
+		buf := bytes.Buffer{}
+		fields, _ := getManagerFields(c)
+		if err := marshalAthensManagerFields(&buf, tag, fields); err != nil {
+			return nil, err
+		}
+		pubKeyBytes, err := c.PublicKey.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write pubKey: %w", err)
+		}
+		buf.Write(pubKeyBytes)
+		return buf.Bytes(), nil
+	case *Transaction:
+		buf := bytes.Buffer{}
+		fields, _ := getManagerFields(c)
+		if err := marshalAthensManagerFields(&buf, tag, fields); err != nil {
+			return nil, err
+		}
+		amount, err := zarith.Encode(c.Amount)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write Amount: %w", err)
+		}
+		buf.Write(amount)
+		destinationBytes, err := c.Destination.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write destination: %w", err)
+		}
+		buf.Write(destinationBytes)
+		buf.WriteByte(serializeBoolean(c.Parameters != nil))
+		if c.Parameters != nil {
+			if c.Parameters.Entrypoint != EntrypointDefault {
+				return nil, xerrors.Errorf("entrypoint %s can't be called in protocol %s", c.Parameters.Entrypoint, ProtocolAthens)
+			}
+			// the default entrypoint is the single byte 0x00 before the length-prefixed value
+			paramsBytes, err := c.Parameters.MarshalBinary()
+			if err != nil {
+				return nil, xerrors.Errorf("failed to write transaction parameters: %w", err)
+			}
+			buf.Write(paramsBytes[1:])
+		}
+		return buf.Bytes(), nil
+	case *Delegation:
+		buf := bytes.Buffer{}
+		fields, _ := getManagerFields(c)
+		if err := marshalAthensManagerFields(&buf, tag, fields); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(serializeBoolean(c.Delegate != nil))
+		if c.Delegate != nil {
+			delegateBytes, err := c.Delegate.EncodePubKeyHash()
+			if err != nil {
+				return nil, xerrors.Errorf("failed to write delegate: %w", err)
+			}
+			buf.Write(delegateBytes)
+		}
+		return buf.Bytes(), nil
+	default:
+		// athens originations also carry a manager key and spendable and delegatable
+		// flags, which Origination does not model
+		return nil, xerrors.Errorf("%T can't be encoded for protocol %s", content, ProtocolAthens)
+	}
+}
+
+// marshalAthensManagerFields writes the tag and the fields shared by manager
+// operations in protocol 004
+func marshalAthensManagerFields(buf *bytes.Buffer, tag byte, fields managerFields) error {
+	buf.WriteByte(tag)
+	sourceBytes, err := fields.Source.MarshalBinary()
+	if err != nil {
+		return xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+	for _, field := range []struct {
+		name  string
+		value *big.Int
+	}{{"Fee", *fields.Fee}, {"Counter", *fields.Counter}, {"GasLimit", *fields.GasLimit}, {"StorageLimit", *fields.StorageLimit}} {
+		encoded, err := zarith.Encode(field.value)
+		if err != nil {
+			return xerrors.Errorf("failed to write %s: %w", field.name, err)
+		}
+		buf.Write(encoded)
+	}
+	return nil
+}
+
+// unmarshalAthensContents decodes the contents laid out as in protocol 004 at the
+// start of data and returns the number of bytes they occupy
+func unmarshalAthensContents(data []byte) (_ OperationContents, _ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	var tag ContentsTag
+	found := false
+	for candidate, encoded := range athensContentsTags {
+		if encoded == data[0] {
+			tag, found = candidate, true
+		}
+	}
+	if !found {
+		return nil, 0, xerrors.Errorf("unexpected content tag %d for protocol %s", data[0], ProtocolAthens)
+	}
+
+	var content contentsUnmarshaler
+	switch tag {
+	case ContentsTagEndorsement:
+		content = &Endorsement{}
+	case ContentsTagSeedNonceRevelation:
+		content = &SeedNonceRevelation{}
+	case ContentsTagDoubleBakingEvidence:
+		content = &DoubleBakingEvidence{}
+	case ContentsTagActivateAccount:
+		content = &AccountActivation{}
+	case ContentsTagProposals:
+		content = &Proposals{}
+	case ContentsTagRevelation:
+		return unmarshalAthensRevelation(data)
+	case ContentsTagTransaction:
+		return unmarshalAthensTransaction(data)
+	case ContentsTagDelegation:
+		return unmarshalAthensDelegation(data)
+	default:
+		return nil, 0, xerrors.Errorf("content tag %d can't be decoded for protocol %s", data[0], ProtocolAthens)
+	}
+	// the layout is unchanged, so decode from a copy with the current tag
+	contentData := append([]byte{byte(tag)}, data[1:]...)
+	bytesRead, err := content.unmarshalBinary(contentData)
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, bytesRead, nil
+}
+
+// unmarshalAthensManagerFields reads the fields shared by manager operations in
+// protocol 004, after the tag, and returns the number of bytes they occupy
+func unmarshalAthensManagerFields(data []byte, fields managerFields) (int, error) {
+	dataPtr := data
+	err := fields.Source.UnmarshalBinary(dataPtr[:ContractIDLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[ContractIDLen:]
+	for _, field := range []struct {
+		name  string
+		value **big.Int
+	}{{"fee", fields.Fee}, {"counter", fields.Counter}, {"gas limit", fields.GasLimit}, {"storage limit", fields.StorageLimit}} {
+		var bytesRead int
+		*field.value, bytesRead, err = zarith.ReadNext(dataPtr)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal %s: %w", field.name, err)
+		}
+		dataPtr = dataPtr[bytesRead:]
+	}
+	return len(data) - len(dataPtr), nil
+}
+
+func unmarshalAthensRevelation(data []byte) (OperationContents, int, error) {
+	revelation := &Revelation{}
+	fields, _ := getManagerFields(revelation)
+	bytesRead, err := unmarshalAthensManagerFields(data[1:], fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	dataPtr := data[1+bytesRead:]
+	bytesRead, err = revelation.PublicKey.unmarshalBinary(dataPtr)
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to unmarshal public key: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+	return revelation, len(data) - len(dataPtr), nil
+}
+
+func unmarshalAthensTransaction(data []byte) (OperationContents, int, error) {
+	transaction := &Transaction{}
+	fields, _ := getManagerFields(transaction)
+	bytesRead, err := unmarshalAthensManagerFields(data[1:], fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	dataPtr := data[1+bytesRead:]
+	transaction.Amount, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to unmarshal amount: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+	err = transaction.Destination.UnmarshalBinary(dataPtr[:ContractIDLen])
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to unmarshal destination: %w", err)
+	}
+	dataPtr = dataPtr[ContractIDLen:]
+	hasParameters, err := deserializeBoolean(dataPtr[0])
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to deserialize presence of transaction parameters: %w", err)
+	}
+	dataPtr = dataPtr[1:]
+	if hasParameters {
+		value, err := readLengthPrefixed(dataPtr)
+		if err != nil {
+			return nil, 0, xerrors.Errorf("failed to unmarshal transaction parameters: %w", err)
+		}
+		rawValue := TransactionParametersValueRawBytes(value)
+		transaction.Parameters = &TransactionParameters{Entrypoint: EntrypointDefault, Value: &rawValue}
+		dataPtr = dataPtr[4+len(value):]
+	}
+	return transaction, len(data) - len(dataPtr), nil
+}
+
+func unmarshalAthensDelegation(data []byte) (OperationContents, int, error) {
+	delegation := &Delegation{}
+	fields, _ := getManagerFields(delegation)
+	bytesRead, err := unmarshalAthensManagerFields(data[1:], fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	dataPtr := data[1+bytesRead:]
+	hasDelegate, err := deserializeBoolean(dataPtr[0])
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to deserialize presence of delegate: %w", err)
+	}
+	dataPtr = dataPtr[1:]
+	if hasDelegate {
+		var delegate ContractID
+		err = delegate.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+		if err != nil {
+			return nil, 0, xerrors.Errorf("failed to unmarshal delegate: %w", err)
+		}
+		delegation.Delegate = &delegate
+		dataPtr = dataPtr[TaggedPubKeyHashLen:]
+	}
+	return delegation, len(data) - len(dataPtr), nil
+}
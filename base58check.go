@@ -31,6 +31,34 @@ func registerBase58CheckPrefix(info base58CheckPrefixInfo) Base58CheckPrefix {
 	return base58CheckPrefix
 }
 
+// RegisterBase58CheckPrefix adds a base58check prefix for values this package does
+// not know about, so that they can be encoded and decoded like the built in ones.
+// Values with the prefix are prefixBytes followed by payloadLength bytes. It errors
+// if values with the new prefix could be confused with those of a registered
+// prefix. Prefixes must be registered during initialization, before any
+// concurrent use of the package.
+func RegisterBase58CheckPrefix(prefixBytes []byte, payloadLength int) (Base58CheckPrefix, error) {
+	if len(prefixBytes) == 0 {
+		return 0, xerrors.New("base58check prefix must not be empty")
+	}
+	if payloadLength <= 0 {
+		return 0, xerrors.Errorf("invalid base58check payload length %d", payloadLength)
+	}
+	for _, existing := range AllBase58CheckPrefixes {
+		existingBytes := existing.PrefixBytes()
+		if len(existingBytes)+existing.PayloadLength() != len(prefixBytes)+payloadLength {
+			continue
+		}
+		if bytes.HasPrefix(existingBytes, prefixBytes) || bytes.HasPrefix(prefixBytes, existingBytes) {
+			return 0, xerrors.Errorf("base58check prefix %v collides with %s", prefixBytes, existing)
+		}
+	}
+	return registerBase58CheckPrefix(base58CheckPrefixInfo{
+		payloadLength: payloadLength,
+		prefixBytes:   append([]byte{}, prefixBytes...),
+	}), nil
+}
+
 // PayloadLength is the number of bytes expected to be in the base58 encoded payload
 func (b Base58CheckPrefix) PayloadLength() int {
 	return base58CheckPrefixInfos[b].payloadLength
@@ -106,6 +134,10 @@ var (
 		payloadLength: 32,
 		prefixBytes:   []byte{79, 199},
 	})
+	PrefixBlockPayloadHash = registerBase58CheckPrefix(base58CheckPrefixInfo{
+		payloadLength: 32,
+		prefixBytes:   []byte{1, 106, 242},
+	})
 	PrefixEd25519PublicKeyHash = registerBase58CheckPrefix(base58CheckPrefixInfo{
 		payloadLength: 20,
 		prefixBytes:   []byte{6, 161, 159},
@@ -195,6 +227,19 @@ var (
 		payloadLength: 20,
 		prefixBytes:   []byte{2, 90, 121},
 	})
+	// PrefixScriptExpressionHash is referenced from https://gitlab.com/tezos/tezos/blob/master/src/proto_alpha/lib_protocol/script_expr_hash.ml
+	PrefixScriptExpressionHash = registerBase58CheckPrefix(base58CheckPrefixInfo{
+		payloadLength: 32,
+		prefixBytes:   []byte{13, 44, 64, 27},
+	})
+	PrefixSmartRollupAddress = registerBase58CheckPrefix(base58CheckPrefixInfo{
+		payloadLength: 20,
+		prefixBytes:   []byte{6, 124, 117},
+	})
+	PrefixSmartRollupCommitmentHash = registerBase58CheckPrefix(base58CheckPrefixInfo{
+		payloadLength: 32,
+		prefixBytes:   []byte{17, 165, 134, 138},
+	})
 )
 
 func checksum(input []byte) [4]byte {
@@ -205,12 +250,30 @@ func checksum(input []byte) [4]byte {
 	return cksum
 }
 
+// base58LengthMargin is the slack, in characters, allowed on top of the longest
+// encoding of a registered prefix
+const base58LengthMargin = 8
+
+// maxBase58CheckEncodedLength returns an upper bound on the length of the
+// base58check encoding of any value with a registered prefix.
+func maxBase58CheckEncodedLength() int {
+	maxDecodedLen := 0
+	for _, info := range base58CheckPrefixInfos {
+		decodedLen := len(info.prefixBytes) + info.payloadLength + 4
+		if decodedLen > maxDecodedLen {
+			maxDecodedLen = decodedLen
+		}
+	}
+	// each byte takes log(256)/log(58) < 1.37 base58 characters
+	return maxDecodedLen*137/100 + 1 + base58LengthMargin
+}
+
 // Base58CheckEncode encodes the given binary payload to base58check. Prefix
 // must be a valid tezos base58check prefix.
 func Base58CheckEncode(b58Prefix Base58CheckPrefix, input []byte) (string, error) {
 	lengthExpected := b58Prefix.PayloadLength()
 	if len(input) != lengthExpected {
-		return "", xerrors.Errorf("unexpected length when encoding base58 input: %d != %d", len(input), lengthExpected)
+		return "", xerrors.Errorf("%w when encoding base58 input: %d != %d", ErrUnexpectedLength, len(input), lengthExpected)
 	}
 
 	prefixBytes := b58Prefix.PrefixBytes()
@@ -224,39 +287,119 @@ func Base58CheckEncode(b58Prefix Base58CheckPrefix, input []byte) (string, error
 // payload and prefix. Errors if the given string does not include a tezos
 // prefix, or if the checksum does not match.
 func Base58CheckDecode(input string) (Base58CheckPrefix, []byte, error) {
+	// base58 decoding is quadratic in the input length, so reject inputs too
+	// long to be any known tezos value before decoding them
+	if maxLen := maxBase58CheckEncodedLength(); len(input) > maxLen {
+		return 0, nil, xerrors.Errorf("base58check input too long: %d characters exceeds maximum of %d", len(input), maxLen)
+	}
 	decoded := base58.Decode(input)
 
 	// checksum
 	if len(decoded) < 5 {
-		return 0, nil, xerrors.Errorf("%s not valid base58check", input)
+		return 0, nil, xerrors.Errorf("%s not valid base58check: %w", input, ErrUnexpectedLength)
 	}
 	var cksum [4]byte
 	copy(cksum[:], decoded[len(decoded)-4:])
 	if checksum(decoded[:len(decoded)-4]) != cksum {
-		return 0, nil, xerrors.Errorf("b58check checksum failed: %s", input)
+		return 0, nil, xerrors.Errorf("%w: %s", ErrInvalidChecksum, input)
 	}
 	decoded = decoded[:len(decoded)-4]
 
-	// prefix
+	// prefix. Prefixes can share leading bytes, so take the longest prefix whose
+	// payload length also matches.
 	var b58prefix Base58CheckPrefix
-	found := false
+	var prefixLen int
+	found, ambiguous, lengthMismatch := false, false, false
 	for _, candidateB58Prefix := range AllBase58CheckPrefixes {
 		binaryPrefix := candidateB58Prefix.PrefixBytes()
-		if bytes.HasPrefix(decoded, binaryPrefix) {
-			b58prefix = candidateB58Prefix
-			decoded = decoded[len(binaryPrefix):]
-			found = true
-			break
+		if !bytes.HasPrefix(decoded, binaryPrefix) {
+			continue
+		}
+		if len(decoded)-len(binaryPrefix) != candidateB58Prefix.PayloadLength() {
+			lengthMismatch = true
+			continue
+		}
+		switch {
+		case !found || len(binaryPrefix) > prefixLen:
+			b58prefix, prefixLen, found, ambiguous = candidateB58Prefix, len(binaryPrefix), true, false
+		case len(binaryPrefix) == prefixLen:
+			ambiguous = true
 		}
 	}
 	if !found {
-		return 0, nil, xerrors.Errorf("unknown base58check prefix: %s", input)
+		if lengthMismatch {
+			return 0, nil, xerrors.Errorf("%w when decoding base58 input: %s", ErrUnexpectedLength, input)
+		}
+		return 0, nil, xerrors.Errorf("%w: %s", ErrUnknownPrefix, input)
 	}
-
-	lengthExpected := b58prefix.PayloadLength()
-	if len(decoded) != lengthExpected {
-		return 0, nil, xerrors.Errorf("unexpected length when decoding base58 input with prefix %s: %d != %d", b58prefix, len(decoded), lengthExpected)
+	if ambiguous {
+		return 0, nil, xerrors.Errorf("ambiguous base58check prefix: %s", input)
 	}
+	decoded = decoded[prefixLen:]
 
 	return b58prefix, decoded, nil
 }
+
+// DecodeBase58Typed decodes the given base58check string like Base58CheckDecode and
+// returns its payload. It errors if the string's prefix is not expectedPrefix.
+func DecodeBase58Typed(input string, expectedPrefix Base58CheckPrefix) ([]byte, error) {
+	b58prefix, decoded, err := Base58CheckDecode(input)
+	if err != nil {
+		return nil, err
+	}
+	if b58prefix != expectedPrefix {
+		return nil, xerrors.Errorf("%w %s for %s, expected %s", ErrUnexpectedPrefix, b58prefix, input, expectedPrefix)
+	}
+	return decoded, nil
+}
+
+// ParseBase58 decodes a tezos base58check string and returns it wrapped in the
+// type this package uses for values with its prefix:
+//   - ContractID for tz1, tz2, tz3 and KT1 addresses
+//   - PublicKey for edpk, sppk and p2pk public keys
+//   - PrivateKey for edsk (64 byte), spsk and p2sk secret keys
+//   - PrivateKeySeed for edsk (32 byte) seeds
+//   - Signature for edsig, spsig1, p2sig and sig signatures
+//   - OperationHash for o... operation hashes
+//   - ProtocolHash for P... protocol hashes
+//   - BranchID for B... block hashes
+//   - ChainID for Net... chain IDs
+//   - ScriptExpressionHash for expr... script expression hashes
+//   - SmartRollupAddress for sr1 smart rollup addresses
+//   - SmartRollupCommitmentHash for src1 smart rollup commitment hashes
+//
+// It errors if s is not valid base58check or its prefix has no such type.
+func ParseBase58(s string) (interface{}, error) {
+	prefix, _, err := Base58CheckDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	switch prefix {
+	case PrefixEd25519PublicKeyHash, PrefixSecp256k1PublicKeyHash, PrefixP256PublicKeyHash, PrefixContractHash:
+		return ContractID(s), nil
+	case PrefixEd25519PublicKey, PrefixSecp256k1PublicKey, PrefixP256PublicKey:
+		return PublicKey(s), nil
+	case PrefixEd25519SecretKey, PrefixSecp256k1SecretKey, PrefixP256SecretKey:
+		return PrivateKey(s), nil
+	case PrefixEd25519Seed:
+		return PrivateKeySeed(s), nil
+	case PrefixEd25519Signature, PrefixSecp256k1Signature, PrefixP256Signature, PrefixGenericSignature:
+		return Signature(s), nil
+	case PrefixOperationHash:
+		return OperationHash(s), nil
+	case PrefixProtocolHash:
+		return ProtocolHash(s), nil
+	case PrefixBlockHash:
+		return BranchID(s), nil
+	case PrefixChainID:
+		return ChainID(s), nil
+	case PrefixScriptExpressionHash:
+		return ScriptExpressionHash(s), nil
+	case PrefixSmartRollupAddress:
+		return SmartRollupAddress(s), nil
+	case PrefixSmartRollupCommitmentHash:
+		return SmartRollupCommitmentHash(s), nil
+	default:
+		return nil, xerrors.Errorf("no typed wrapper for base58check prefix %s", prefix)
+	}
+}
@@ -1,7 +1,9 @@
 package tezosprotocol_test
 
 import (
+	"bytes"
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
@@ -50,6 +52,11 @@ var testCases = []b58CheckTestCase{{
 	Prefix:             tezosprotocol.PrefixChainID,
 	Base58CheckEncoded: "NetXdQprcVkpaWU",
 	String:             "Net(15)",
+}, {
+	PayloadHex:         "0000000000000000000000000000000000000000000000000000000000000000",
+	Prefix:             tezosprotocol.PrefixScriptExpressionHash,
+	Base58CheckEncoded: "exprtWsu7N8st7XBhS685Qa2B4xP6TuTN9ve9UPCU29fV94ySDo5Va",
+	String:             "expr(54)",
 }}
 
 func TestBase58CheckEncode(t *testing.T) {
@@ -97,3 +104,97 @@ func TestBase58CheckDecodeNegativeCases(t *testing.T) {
 	require.Error(err)
 	require.Contains(err.Error(), "unexpected length")
 }
+
+func TestBase58CheckDecodeAllPrefixes(t *testing.T) {
+	require := require.New(t)
+	// every registered prefix must decode back to itself, whichever other prefixes
+	// share its leading bytes
+	for _, prefix := range tezosprotocol.AllBase58CheckPrefixes {
+		for _, fill := range []byte{0x00, 0xff} {
+			payload := bytes.Repeat([]byte{fill}, prefix.PayloadLength())
+			encoded, err := tezosprotocol.Base58CheckEncode(prefix, payload)
+			require.NoError(err)
+			decodedPrefix, decoded, err := tezosprotocol.Base58CheckDecode(encoded)
+			require.NoError(err, encoded)
+			require.Equal(prefix, decodedPrefix, encoded)
+			require.Equal(payload, decoded, encoded)
+		}
+	}
+}
+
+// prefixes are registered during initialization
+var registeredPrefix, registeredPrefixErr = tezosprotocol.RegisterBase58CheckPrefix([]byte{255, 255, 255}, 10)
+
+func TestRegisterBase58CheckPrefix(t *testing.T) {
+	require := require.New(t)
+	require.NoError(registeredPrefixErr)
+	prefix := registeredPrefix
+	payload := bytes.Repeat([]byte{7}, 10)
+	encoded, err := tezosprotocol.Base58CheckEncode(prefix, payload)
+	require.NoError(err)
+	decodedPrefix, decoded, err := tezosprotocol.Base58CheckDecode(encoded)
+	require.NoError(err)
+	require.Equal(prefix, decodedPrefix)
+	require.Equal(payload, decoded)
+
+	// registered prefixes can't be shadowed
+	_, err = tezosprotocol.RegisterBase58CheckPrefix([]byte{255, 255, 255}, 10)
+	require.Error(err)
+	_, err = tezosprotocol.RegisterBase58CheckPrefix(tezosprotocol.PrefixEd25519PublicKeyHash.PrefixBytes()[:2], 21)
+	require.Error(err)
+
+	_, err = tezosprotocol.RegisterBase58CheckPrefix(nil, 10)
+	require.Error(err)
+	_, err = tezosprotocol.RegisterBase58CheckPrefix([]byte{255, 255, 254}, 0)
+	require.Error(err)
+}
+
+func TestDecodeBase58Typed(t *testing.T) {
+	require := require.New(t)
+	payload, err := tezosprotocol.DecodeBase58Typed("NetXdQprcVkpaWU", tezosprotocol.PrefixChainID)
+	require.NoError(err)
+	require.Equal("7a06a770", hex.EncodeToString(payload))
+
+	_, err = tezosprotocol.DecodeBase58Typed("NetXdQprcVkpaWU", tezosprotocol.PrefixBlockHash)
+	require.Error(err)
+	require.Contains(err.Error(), "unexpected base58check prefix")
+
+	_, err = tezosprotocol.DecodeBase58Typed("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSR", tezosprotocol.PrefixEd25519PublicKeyHash)
+	require.Error(err)
+	require.Contains(err.Error(), "checksum")
+}
+
+func TestBase58CheckDecodeRejectsLongInput(t *testing.T) {
+	require := require.New(t)
+	// the length is checked before decoding, which is quadratic in the input length,
+	// so the error reports the length rather than a decoding failure
+	input := strings.Repeat("z", 1<<20)
+	_, _, err := tezosprotocol.Base58CheckDecode(input)
+	require.Error(err)
+	require.Contains(err.Error(), "too long: 1048576 characters")
+
+	_, _, err = tezosprotocol.Base58CheckDecode(strings.Repeat("z", 36))
+	require.Error(err)
+	require.NotContains(err.Error(), "too long")
+}
+
+func TestParseBase58(t *testing.T) {
+	require := require.New(t)
+	for input, expected := range map[string]interface{}{
+		"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx":                   tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		"KT1WfRb2j1YPot5PR1CRPKowiteVmKGaA5NA":                   tezosprotocol.ContractID("KT1WfRb2j1YPot5PR1CRPKowiteVmKGaA5NA"),
+		"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav": tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"),
+		"onvk5LwVA1AXnUEvcz17HE2jt2DLkYbqxkbboX53utEJQ56sThr":    tezosprotocol.OperationHash("onvk5LwVA1AXnUEvcz17HE2jt2DLkYbqxkbboX53utEJQ56sThr"),
+		"BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB":    tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		"NetXdQprcVkpaWU":                                        tezosprotocol.ChainID("NetXdQprcVkpaWU"),
+		"sr17bar6JpYEpsAmkR4MDYUDwxpSiX4RKUH8":                   tezosprotocol.SmartRollupAddress("sr17bar6JpYEpsAmkR4MDYUDwxpSiX4RKUH8"),
+		"src12jLsojB83XmKRnVT4ZLyiQcBQMfejEk32qF8ciT3Qt7wzH67bB": tezosprotocol.SmartRollupCommitmentHash("src12jLsojB83XmKRnVT4ZLyiQcBQMfejEk32qF8ciT3Qt7wzH67bB"),
+	} {
+		parsed, err := tezosprotocol.ParseBase58(input)
+		require.NoError(err, input)
+		require.Equal(expected, parsed, input)
+	}
+
+	_, err := tezosprotocol.ParseBase58("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSR")
+	require.Error(err)
+}
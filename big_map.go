@@ -0,0 +1,71 @@
+package tezosprotocol
+
+import (
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// packWatermark is the byte the PACK instruction prepends to serialized values
+const packWatermark byte = 0x05
+
+// ScriptExpressionHash is the base58check-encoded hash of a packed Michelson
+// value ("expr..."). The node indexes big_map values by the script expression
+// hash of their key.
+type ScriptExpressionHash string
+
+// BigMapKeyHash computes the script expression hash used to look up key in a
+// big_map, i.e. the blake2b-256 hash of the packed key. The key must be a value
+// of a comparable type, in the same (optimized) form the contract would PACK it.
+func BigMapKeyHash(key MichelineNode) (ScriptExpressionHash, error) {
+	if err := validateComparable(key); err != nil {
+		return "", xerrors.Errorf("invalid big_map key: %w", err)
+	}
+	keyBytes, err := key.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("failed to marshal big_map key: %w", err)
+	}
+	hash := blake2b.Sum256(append([]byte{packWatermark}, keyBytes...))
+	encoded, err := Base58CheckEncode(PrefixScriptExpressionHash, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return ScriptExpressionHash(encoded), nil
+}
+
+// validateComparable checks that node is a value of a comparable Michelson type:
+// a literal, or a Unit/bool/option/or/pair constructor whose arguments are
+// themselves comparable. Sequences (lists, sets, maps and lambdas) are not.
+func validateComparable(node MichelineNode) error {
+	switch n := node.(type) {
+	case *MichelineInt, *MichelineString, *MichelineBytes:
+		return nil
+	case *MichelineSeq:
+		return xerrors.New("sequence is not a comparable value")
+	case *MichelinePrim:
+		var expectedArgs int
+		switch n.Prim {
+		case PrimD_Unit, PrimD_True, PrimD_False, PrimD_None:
+			expectedArgs = 0
+		case PrimD_Some, PrimD_Left, PrimD_Right:
+			expectedArgs = 1
+		case PrimD_Pair:
+			if len(n.Args) < 2 {
+				return xerrors.Errorf("pair must have at least 2 arguments, saw %d", len(n.Args))
+			}
+			expectedArgs = len(n.Args)
+		default:
+			return xerrors.Errorf("primitive %s is not a comparable value", Prim(n.Prim))
+		}
+		if len(n.Args) != expectedArgs {
+			return xerrors.Errorf("primitive %s expects %d arguments, saw %d", Prim(n.Prim), expectedArgs, len(n.Args))
+		}
+		for _, arg := range n.Args {
+			if err := validateComparable(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return xerrors.Errorf("unsupported micheline node %T", node)
+	}
+}
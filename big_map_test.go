@@ -0,0 +1,55 @@
+package tezosprotocol_test
+
+import (
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigMapKeyHash(t *testing.T) {
+	require := require.New(t)
+	pair := func(left, right tezosprotocol.MichelineNode) tezosprotocol.MichelineNode {
+		return &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{left, right}}
+	}
+	testCases := []struct {
+		key      tezosprotocol.MichelineNode
+		expected tezosprotocol.ScriptExpressionHash
+	}{
+		// octez-client hash data '"hello"' of type string
+		{michelineString("hello"), "exprtsjEVVZk3Gm82U9wEs8kvwRiQwUT7zipJwvCeFMNsApe2tQ15s"},
+		// octez-client hash data 0 of type nat, the key of token 0 in FA2 token_metadata
+		{michelineInt(0), "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC"},
+		{michelineInt(1), "expru2dKqDfZG8hu4wNGkiyunvq2hdSKuVYtcKta7BWP6Q18oNxKjS"},
+		// blake2b-256 of the packed bytes 05 0707 0001 0002
+		{pair(michelineInt(1), michelineInt(2)), "expruuVqLi9YeXfHPkysBn1aj3TZpQM9PT1vXY6F9ZwVWzEAVw9VEQ"},
+		// blake2b-256 of the packed bytes 05 0707 010000000568656c6c6f 0041
+		{pair(michelineString("hello"), michelineInt(-1)), "expru2bEnobr4zgoepwpLJmBpEmwKA9pKenNe7ETJoQG7htidh1BwG"},
+	}
+	for _, testCase := range testCases {
+		hash, err := tezosprotocol.BigMapKeyHash(testCase.key)
+		require.NoError(err)
+		require.Equal(testCase.expected, hash)
+	}
+}
+
+func TestBigMapKeyHashRejectsNonComparableKey(t *testing.T) {
+	require := require.New(t)
+	first := tezosprotocol.MichelineString("a")
+	second := tezosprotocol.MichelineString("b")
+	key := tezosprotocol.MichelineSeq{&first, &second}
+	_, err := tezosprotocol.BigMapKeyHash(&key)
+	require.Error(err)
+	require.Contains(err.Error(), "not a comparable value")
+}
+
+func TestBigMapKeyHashNamesPrimitive(t *testing.T) {
+	require := require.New(t)
+	_, err := tezosprotocol.BigMapKeyHash(&tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_unit})
+	require.Error(err)
+	require.Contains(err.Error(), "primitive unit is not a comparable value")
+
+	_, err = tezosprotocol.BigMapKeyHash(&tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Some})
+	require.Error(err)
+	require.Contains(err.Error(), "primitive Some expects 1 arguments, saw 0")
+}
@@ -0,0 +1,190 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// BlockHeader models a block header: the shell header shared by all protocols,
+// followed by protocol-specific data.
+// Reference: http://tezos.gitlab.io/mainnet/api/p2p.html#block-header-shell
+type BlockHeader struct {
+	Level          int32
+	Proto          uint8
+	Predecessor    BranchID
+	Timestamp      int64
+	ValidationPass uint8
+	OperationsHash OperationListListHash
+	Fitness        [][]byte
+	Context        ContextHash
+	// ProtocolData is the protocol-specific part of the header. It is opaque to this
+	// package and runs to the end of the header. When signing, it must not include
+	// the signature.
+	ProtocolData []byte
+}
+
+func (h *BlockHeader) String() string {
+	return fmt.Sprintf("%#v", h)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (h *BlockHeader) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// level
+	err := binary.Write(&buf, binary.BigEndian, h.Level)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write level: %w", err)
+	}
+
+	// proto
+	buf.WriteByte(h.Proto)
+
+	// predecessor
+	predecessorBytes, err := h.Predecessor.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write predecessor: %w", err)
+	}
+	buf.Write(predecessorBytes)
+
+	// timestamp
+	err = binary.Write(&buf, binary.BigEndian, h.Timestamp)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write timestamp: %w", err)
+	}
+
+	// validation pass
+	buf.WriteByte(h.ValidationPass)
+
+	// operations hash
+	operationsHashBytes, err := h.OperationsHash.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write operations hash: %w", err)
+	}
+	buf.Write(operationsHashBytes)
+
+	// fitness: a length-prefixed list of length-prefixed byte strings
+	fitnessBuf := bytes.Buffer{}
+	for _, fitnessElement := range h.Fitness {
+		err = binary.Write(&fitnessBuf, binary.BigEndian, uint32(len(fitnessElement)))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write fitness: %w", err)
+		}
+		fitnessBuf.Write(fitnessElement)
+	}
+	err = binary.Write(&buf, binary.BigEndian, uint32(fitnessBuf.Len()))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write fitness length: %w", err)
+	}
+	buf.Write(fitnessBuf.Bytes())
+
+	// context
+	contextBytes, err := h.Context.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write context: %w", err)
+	}
+	buf.Write(contextBytes)
+
+	// protocol data
+	buf.Write(h.ProtocolData)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (h *BlockHeader) UnmarshalBinary(data []byte) (err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	*h = BlockHeader{}
+	dataPtr := data
+
+	// level
+	h.Level, err = readInt32(dataPtr[:4])
+	if err != nil {
+		return xerrors.Errorf("failed to read level: %w", err)
+	}
+	dataPtr = dataPtr[4:]
+
+	// proto
+	h.Proto = dataPtr[0]
+	dataPtr = dataPtr[1:]
+
+	// predecessor
+	err = h.Predecessor.UnmarshalBinary(dataPtr[:BlockHashLen])
+	if err != nil {
+		return xerrors.Errorf("failed to read predecessor: %w", err)
+	}
+	dataPtr = dataPtr[BlockHashLen:]
+
+	// timestamp
+	h.Timestamp = int64(binary.BigEndian.Uint64(dataPtr[:8]))
+	dataPtr = dataPtr[8:]
+
+	// validation pass
+	h.ValidationPass = dataPtr[0]
+	dataPtr = dataPtr[1:]
+
+	// operations hash
+	err = h.OperationsHash.UnmarshalBinary(dataPtr[:OperationListListHashLen])
+	if err != nil {
+		return xerrors.Errorf("failed to read operations hash: %w", err)
+	}
+	dataPtr = dataPtr[OperationListListHashLen:]
+
+	// fitness
+	fitnessLen := binary.BigEndian.Uint32(dataPtr[:4])
+	dataPtr = dataPtr[4:]
+	if uint64(fitnessLen) > uint64(len(dataPtr)) {
+		return xerrors.Errorf("fitness length %d exceeds remaining %d bytes", fitnessLen, len(dataPtr))
+	}
+	fitnessPtr := dataPtr[:fitnessLen]
+	dataPtr = dataPtr[fitnessLen:]
+	for len(fitnessPtr) > 0 {
+		elementLen := binary.BigEndian.Uint32(fitnessPtr[:4])
+		fitnessPtr = fitnessPtr[4:]
+		if uint64(elementLen) > uint64(len(fitnessPtr)) {
+			return xerrors.Errorf("fitness element length %d exceeds remaining %d bytes", elementLen, len(fitnessPtr))
+		}
+		h.Fitness = append(h.Fitness, append([]byte{}, fitnessPtr[:elementLen]...))
+		fitnessPtr = fitnessPtr[elementLen:]
+	}
+
+	// context
+	err = h.Context.UnmarshalBinary(dataPtr[:ContextHashLen])
+	if err != nil {
+		return xerrors.Errorf("failed to read context: %w", err)
+	}
+	dataPtr = dataPtr[ContextHashLen:]
+
+	// protocol data
+	if len(dataPtr) > 0 {
+		h.ProtocolData = append([]byte{}, dataPtr...)
+	}
+
+	return nil
+}
+
+// SignBlockHeader signs the block header for the chain identified by chainID. Like
+// octez, it signs TenderbakeBlockHeaderWatermark followed by the chain ID and the
+// header. The header's ProtocolData must not already include a signature.
+func SignBlockHeader(h *BlockHeader, chainID ChainID, key PrivateKey) (Signature, error) {
+	chainIDBytes, err := chainID.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("invalid chain ID: %w", err)
+	}
+	headerBytes, err := h.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("failed to marshal block header: %s: %w", h, err)
+	}
+	return signGeneric(TenderbakeBlockHeaderWatermark, append(chainIDBytes, headerBytes...), key)
+}
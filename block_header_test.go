@@ -0,0 +1,82 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHeaderRoundTrip(t *testing.T) {
+	require := require.New(t)
+	header := &tezosprotocol.BlockHeader{
+		Level:          1,
+		Proto:          2,
+		Predecessor:    tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Timestamp:      1600000000,
+		ValidationPass: 4,
+		OperationsHash: tezosprotocol.OperationListListHash("LLoZTDxXfCKc61HAuZ7RXJgJ2FQx1RHAbboSYBv1XVa1GmiWTDDdQ"),
+		Fitness:        [][]byte{{0x01}, {0x00, 0x00, 0x00, 0x05}},
+		Context:        tezosprotocol.ContextHash("CoUuLkEybAy1Lvwc8qt1oRCY59ihYn3mZbVLJw8f6xEE927D36p3"),
+		ProtocolData:   []byte{0xde, 0xad},
+	}
+	headerBytes, err := header.MarshalBinary()
+	require.NoError(err)
+	expected := "00000001" + "02" +
+		"e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f" +
+		"000000005f5e1000" + "04" +
+		"1111111111111111111111111111111111111111111111111111111111111111" +
+		"0000000d" + "0000000101" + "0000000400000005" +
+		"2222222222222222222222222222222222222222222222222222222222222222" +
+		"dead"
+	require.Equal(expected, hex.EncodeToString(headerBytes))
+
+	decoded := &tezosprotocol.BlockHeader{}
+	require.NoError(decoded.UnmarshalBinary(headerBytes))
+	require.Equal(header, decoded)
+
+	// truncated
+	require.Error(decoded.UnmarshalBinary(headerBytes[:80]))
+	// fitness longer than the header
+	require.Error(decoded.UnmarshalBinary(headerBytes[:83]))
+}
+
+func TestSignBlockHeader(t *testing.T) {
+	require := require.New(t)
+	header := &tezosprotocol.BlockHeader{
+		Level:          1,
+		Predecessor:    tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		OperationsHash: tezosprotocol.OperationListListHash("LLoZTDxXfCKc61HAuZ7RXJgJ2FQx1RHAbboSYBv1XVa1GmiWTDDdQ"),
+		Context:        tezosprotocol.ContextHash("CoUuLkEybAy1Lvwc8qt1oRCY59ihYn3mZbVLJw8f6xEE927D36p3"),
+	}
+	chainID := tezosprotocol.ChainID("NetXdQprcVkpaWU")
+	privateKey := tezosprotocol.PrivateKey("edskRc9Pr1NKUW9x6kAZb9cFerBWMo9X9dW4fXwzzL2rvKyKPfdJaJVUcYCfR37sbBujAXJXVJZoCXsUHzfhNcWuqy9aGunQPk")
+
+	// ed25519 signatures are deterministic. This one is of blake2b(0x11 || chain ID ||
+	// header), as signed by octez bakers, computed with an independent RFC 8032
+	// implementation.
+	signature, err := tezosprotocol.SignBlockHeader(header, chainID, privateKey)
+	require.NoError(err)
+	expected := tezosprotocol.Signature("edsigu4Qw5dW6cKNkxRg3wyK3Wmyq1p9oNpoopVVhG278FCrNNnNeTMTeXYVUViZiH1E9LeHsqknr53UFrdkuCyxuEv8EtBHHHg")
+	require.Equal(expected, signature)
+
+	publicKey, err := privateKey.PublicKey()
+	require.NoError(err)
+	cryptoPublicKey, err := publicKey.CryptoPublicKey()
+	require.NoError(err)
+	headerBytes, err := header.MarshalBinary()
+	require.NoError(err)
+	chainIDBytes, err := chainID.MarshalBinary()
+	require.NoError(err)
+	ok, err := signature.Verify(tezosprotocol.TenderbakeBlockHeaderWatermark, append(chainIDBytes, headerBytes...), cryptoPublicKey)
+	require.NoError(err)
+	require.True(ok)
+	// the chain ID is part of the signed payload
+	ok, err = signature.Verify(tezosprotocol.TenderbakeBlockHeaderWatermark, headerBytes, cryptoPublicKey)
+	require.NoError(err)
+	require.False(ok)
+
+	_, err = tezosprotocol.SignBlockHeader(header, tezosprotocol.ChainID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"), privateKey)
+	require.Error(err)
+}
@@ -0,0 +1,50 @@
+package tezosprotocol
+
+import (
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// ChainIDLen is the length in bytes of a serialized chain ID
+const ChainIDLen = 4
+
+// ChainID encodes a tezos chain ID in base58check encoding
+type ChainID string
+
+// ChainIDFromBlockHash derives the chain ID of the network whose genesis block
+// has the given hash: the first 4 bytes of the blake2b-256 hash of the block
+// hash.
+// Reference: https://gitlab.com/tezos/tezos/blob/master/src/lib_crypto/chain_id.ml
+func ChainIDFromBlockHash(genesisBlockHash BranchID) (ChainID, error) {
+	blockHashBytes, err := genesisBlockHash.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("invalid block hash: %w", err)
+	}
+	hash := blake2b.Sum256(blockHashBytes)
+	var chainID ChainID
+	err = chainID.UnmarshalBinary(hash[:ChainIDLen])
+	return chainID, err
+}
+
+// String implements fmt.Stringer, returning the base58check encoding
+func (c ChainID) String() string {
+	return string(c)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (c ChainID) MarshalBinary() ([]byte, error) {
+	return DecodeBase58Typed(string(c), PrefixChainID)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *ChainID) UnmarshalBinary(data []byte) error {
+	if len(data) != ChainIDLen {
+		return xerrors.Errorf("expect chain ID to be %d bytes but received %d: %w", ChainIDLen, len(data), ErrUnexpectedLength)
+	}
+	b58checkEncoded, err := Base58CheckEncode(PrefixChainID, data)
+	if err != nil {
+		return err
+	}
+	*c = ChainID(b58checkEncoded)
+	return nil
+}
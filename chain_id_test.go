@@ -0,0 +1,36 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainIDFromBlockHash(t *testing.T) {
+	require := require.New(t)
+	chainID, err := tezosprotocol.ChainIDFromBlockHash("BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2")
+	require.NoError(err)
+	require.Equal(tezosprotocol.ChainID("NetXdQprcVkpaWU"), chainID)
+
+	_, err = tezosprotocol.ChainIDFromBlockHash("NetXdQprcVkpaWU")
+	require.Error(err)
+}
+
+func TestChainIDBinaryRoundTrip(t *testing.T) {
+	require := require.New(t)
+	chainID := tezosprotocol.ChainID("NetXdQprcVkpaWU")
+	chainIDBytes, err := chainID.MarshalBinary()
+	require.NoError(err)
+	require.Equal("7a06a770", hex.EncodeToString(chainIDBytes))
+
+	var decoded tezosprotocol.ChainID
+	require.NoError(decoded.UnmarshalBinary(chainIDBytes))
+	require.Equal(chainID, decoded)
+	require.Equal("NetXdQprcVkpaWU", decoded.String())
+
+	require.Error(decoded.UnmarshalBinary(chainIDBytes[:3]))
+	_, err = tezosprotocol.ChainID("BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2").MarshalBinary()
+	require.Error(err)
+}
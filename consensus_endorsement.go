@@ -0,0 +1,114 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// BlockPayloadHashLen is the length in bytes of a serialized block payload hash. The
+// RPC shows payload hashes base58check-encoded with PrefixBlockPayloadHash ("vh...").
+const BlockPayloadHashLen = 32
+
+// ConsensusEndorsement models the endorsement operation type of Tenderbake
+// protocols, which endorses a block by its level, round and payload hash. The
+// legacy Endorsement, which carries only a level, remains for older protocols.
+type ConsensusEndorsement struct {
+	Slot             uint16
+	Level            int32
+	Round            int32
+	BlockPayloadHash [BlockPayloadHashLen]byte
+}
+
+func (e *ConsensusEndorsement) String() string {
+	return fmt.Sprintf("%#v", e)
+}
+
+// GetTag implements OperationContents
+func (e *ConsensusEndorsement) GetTag() ContentsTag {
+	return ContentsTagConsensusEndorsement
+}
+
+// GetSource returns the operation's source. Like legacy endorsements, consensus
+// endorsements are identified by the key that signs them, so this is always empty.
+func (e *ConsensusEndorsement) GetSource() ContractID {
+	return ""
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (e *ConsensusEndorsement) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(e.GetTag()))
+
+	// slot, level and round
+	for _, field := range []interface{}{e.Slot, e.Level, e.Round} {
+		err := binary.Write(&buf, binary.BigEndian, field)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write %T: %w", field, err)
+		}
+	}
+
+	// block payload hash
+	buf.Write(e.BlockPayloadHash[:])
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (e *ConsensusEndorsement) UnmarshalBinary(data []byte) error {
+	_, err := e.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the consensus endorsement at the start of data and returns the number
+// of bytes it occupies
+func (e *ConsensusEndorsement) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagConsensusEndorsement {
+		return 0, xerrors.Errorf("invalid tag for consensus endorsement. Expected %d, saw %d", ContentsTagConsensusEndorsement, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// slot
+	e.Slot = binary.BigEndian.Uint16(dataPtr[:2])
+	dataPtr = dataPtr[2:]
+
+	// level
+	e.Level, err = readInt32(dataPtr[:4])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal level: %w", err)
+	}
+	dataPtr = dataPtr[4:]
+
+	// round
+	e.Round, err = readInt32(dataPtr[:4])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal round: %w", err)
+	}
+	dataPtr = dataPtr[4:]
+
+	// block payload hash
+	if len(dataPtr) < BlockPayloadHashLen {
+		return 0, xerrors.Errorf("expected %d bytes of block payload hash, got %d", BlockPayloadHashLen, len(dataPtr))
+	}
+	copy(e.BlockPayloadHash[:], dataPtr[:BlockPayloadHashLen])
+	dataPtr = dataPtr[BlockPayloadHashLen:]
+
+	return len(data) - len(dataPtr), nil
+}
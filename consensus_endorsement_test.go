@@ -0,0 +1,49 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusEndorsement(t *testing.T) {
+	require := require.New(t)
+	// the endorsement the RPC shows as
+	//   {"kind": "endorsement", "slot": 7, "level": 2728368, "round": 3,
+	//    "block_payload_hash": "vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf"}
+	// laid out as in the octez operation encoding: tag 21, a uint16 slot, int32 level
+	// and round, then the payload hash. Every field is non-zero, so reading a field
+	// at the wrong offset or width changes the decoded values.
+	encodedHex := "15" + "0007" + "0029a1b0" + "00000003" + "6820128b76deb07f57606736d66069d23d48386a4e6e9239c7cd2236ad7e88e6"
+	encoded, err := hex.DecodeString(encodedHex)
+	require.NoError(err)
+
+	endorsement := &tezosprotocol.ConsensusEndorsement{}
+	require.NoError(endorsement.UnmarshalBinary(encoded))
+	require.Equal(uint16(7), endorsement.Slot)
+	require.Equal(int32(2728368), endorsement.Level)
+	require.Equal(int32(3), endorsement.Round)
+	prefix, payloadHash, err := tezosprotocol.Base58CheckDecode("vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrefixBlockPayloadHash, prefix)
+	require.Equal(payloadHash, endorsement.BlockPayloadHash[:])
+
+	reencoded, err := endorsement.MarshalBinary()
+	require.NoError(err)
+	require.Equal(encodedHex, hex.EncodeToString(reencoded))
+
+	// decodes as part of an operation, and can't be batched with manager operations
+	branchBytes, err := tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB").MarshalBinary()
+	require.NoError(err)
+	operation := &tezosprotocol.Operation{}
+	require.NoError(operation.UnmarshalBinary(append(branchBytes, encoded...)))
+	require.Equal([]tezosprotocol.OperationContents{endorsement}, operation.Contents)
+	operation.Contents = append(operation.Contents, tezosprotocol.NewTransfer("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", 1))
+	_, err = operation.MarshalBinary()
+	require.Error(err)
+
+	// truncated
+	require.Error(endorsement.UnmarshalBinary(encoded[:20]))
+}
@@ -12,6 +12,30 @@ const (
 	ContentsTagOrigination ContentsTag = 109
 	// ContentsTagDelegation is the tag for delegations
 	ContentsTagDelegation ContentsTag = 110
+	// ContentsTagRegisterGlobalConstant is the tag for global constant registrations
+	ContentsTagRegisterGlobalConstant ContentsTag = 111
+	// ContentsTagSetDepositsLimit is the tag for deposits limits
+	ContentsTagSetDepositsLimit ContentsTag = 112
+	// ContentsTagIncreasePaidStorage is the tag for paid storage increases
+	ContentsTagIncreasePaidStorage ContentsTag = 113
+	// ContentsTagTransferTicket is the tag for ticket transfers
+	ContentsTagTransferTicket ContentsTag = 158
+	// ContentsTagSmartRollupOriginate is the tag for smart rollup originations
+	ContentsTagSmartRollupOriginate ContentsTag = 200
+	// ContentsTagSmartRollupAddMessages is the tag for smart rollup inbox messages
+	ContentsTagSmartRollupAddMessages ContentsTag = 201
 	// ContentsTagEndorsement is the tag for endorsements
 	ContentsTagEndorsement ContentsTag = 0
+	// ContentsTagSeedNonceRevelation is the tag for seed nonce revelations
+	ContentsTagSeedNonceRevelation ContentsTag = 1
+	// ContentsTagDoubleBakingEvidence is the tag for double baking evidence
+	ContentsTagDoubleBakingEvidence ContentsTag = 3
+	// ContentsTagActivateAccount is the tag for account activations
+	ContentsTagActivateAccount ContentsTag = 4
+	// ContentsTagProposals is the tag for proposals
+	ContentsTagProposals ContentsTag = 5
+	// ContentsTagFailingNoop is the tag for failing noops
+	ContentsTagFailingNoop ContentsTag = 17
+	// ContentsTagConsensusEndorsement is the tag for Tenderbake endorsements
+	ContentsTagConsensusEndorsement ContentsTag = 21
 )
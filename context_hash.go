@@ -0,0 +1,27 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// ContextHashLen is the length in bytes of a serialized context hash
+const ContextHashLen = 32
+
+// ContextHash encodes a context hash in base58check encoding
+type ContextHash string
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (c ContextHash) MarshalBinary() ([]byte, error) {
+	return DecodeBase58Typed(string(c), PrefixContextHash)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *ContextHash) UnmarshalBinary(data []byte) error {
+	if len(data) != ContextHashLen {
+		return xerrors.Errorf("expect context hash to be %d bytes but received %d: %w", ContextHashLen, len(data), ErrUnexpectedLength)
+	}
+	b58checkEncoded, err := Base58CheckEncode(PrefixContextHash, data)
+	if err != nil {
+		return err
+	}
+	*c = ContextHash(b58checkEncoded)
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/binary"
 
 	"golang.org/x/crypto/blake2b"
-	"golang.org/x/crypto/ed25519"
 	"golang.org/x/xerrors"
 )
 
@@ -41,40 +40,14 @@ const (
 // base58check encoding.
 type ContractID string
 
-// NewContractIDFromPublicKey creates a new contract ID from a public key.
-// AccountType is "implicit."
+// NewContractIDFromPublicKey creates a new contract ID from a public key of any of
+// the supported curves, giving a tz1, tz2 or tz3 address. AccountType is "implicit."
+// Unlike PublicKey.Hash, it errors if the key is not a valid point on its curve.
 func NewContractIDFromPublicKey(pubKey PublicKey) (ContractID, error) {
-	// pubkey bytes
-	cryptoPubKey, err := pubKey.CryptoPublicKey()
-	if err != nil {
-		return "", err
-	}
-	var pubKeyBytes []byte
-	switch key := cryptoPubKey.(type) {
-	case ed25519.PublicKey:
-		pubKeyBytes = []byte(key)
-	default:
-		return "", xerrors.Errorf("unsupported public key type %T", cryptoPubKey)
-	}
-
-	// pubkey hash
-	pubKeyHash, err := blake2b.New(PubKeyHashLen, nil)
-	if err != nil {
-		panic(xerrors.Errorf("failed to create blake2b hash: %w", err))
+	if _, err := pubKey.CryptoPublicKey(); err != nil {
+		return "", xerrors.Errorf("invalid public key %s: %w", pubKey, err)
 	}
-	_, err = pubKeyHash.Write(pubKeyBytes)
-	if err != nil {
-		panic(xerrors.Errorf("failed to write pubkey to hash: %w", err))
-	}
-	pubKeyHashBytes := pubKeyHash.Sum([]byte{})
-
-	// base58check
-	tz1Addr, err := Base58CheckEncode(PrefixEd25519PublicKeyHash, pubKeyHashBytes)
-	if err != nil {
-		return "", xerrors.Errorf("failed to base58check encode hash: %w", err)
-	}
-
-	return ContractID(tz1Addr), nil
+	return pubKey.Hash()
 }
 
 // NewContractIDFromOrigination returns the address (contract ID) of an account that
@@ -118,6 +91,20 @@ func NewContractIDFromOrigination(operationHash OperationHash, nonce uint32) (Co
 	return contractID, err
 }
 
+// NewContractIDsFromOrigination returns the addresses of the first count accounts
+// originated by this operation, in origination order. See NewContractIDFromOrigination.
+func NewContractIDsFromOrigination(operationHash OperationHash, count uint32) ([]ContractID, error) {
+	contractIDs := make([]ContractID, 0, count)
+	for nonce := uint32(0); nonce < count; nonce++ {
+		contractID, err := NewContractIDFromOrigination(operationHash, nonce)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to derive originated address %d: %w", nonce, err)
+		}
+		contractIDs = append(contractIDs, contractID)
+	}
+	return contractIDs, nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler. Reference:
 // http://tezos.gitlab.io/mainnet/api/p2p.html#contract-id-22-bytes-8-bit-tag
 func (c ContractID) MarshalBinary() ([]byte, error) {
@@ -156,7 +143,7 @@ func (c ContractID) MarshalBinary() ([]byte, error) {
 		buf.WriteByte(0)
 
 	default:
-		return nil, xerrors.Errorf("unexpected base58check prefix %s in %s", b58prefix, c)
+		return nil, xerrors.Errorf("%w %s in %s", ErrUnexpectedPrefix, b58prefix, c)
 	}
 
 	return buf.Bytes(), nil
@@ -165,46 +152,87 @@ func (c ContractID) MarshalBinary() ([]byte, error) {
 // UnmarshalBinary implements encoding.BinaryUnmarshaler. It accepts a 22 byte $contract_id or
 // a 21 byte $public_key_hash
 func (c *ContractID) UnmarshalBinary(data []byte) error {
-	var contractIDTag ContractIDTag
+	var publicKeyHash PublicKeyHash
 	switch len(data) {
 	case ContractIDLen:
-		contractIDTag = ContractIDTag(data[0])
-	case TaggedPubKeyHashLen:
-		// prepend a byte to data so we can pretend the caller supplied a $contract_id instead of
-		// a $public_key_hash and reuse the same parsing code below
-		data = append([]byte{byte(ContractIDTagImplicit)}, data...)
-		contractIDTag = ContractIDTagImplicit
-	default:
-		return xerrors.Errorf("expected %d bytes for contract ID or %d bytes for tagged public key hash; received %d", ContractIDLen, TaggedPubKeyHashLen, len(data))
-	}
-	switch contractIDTag {
-	case ContractIDTagImplicit:
-		pubKeyHashTag := PubKeyHashTag(data[1])
-		pubKeyHash := data[2:]
-		switch pubKeyHashTag {
-		case PubKeyHashTagEd25519:
-			encoded, err := Base58CheckEncode(PrefixEd25519PublicKeyHash, pubKeyHash)
-			*c = ContractID(encoded)
-			return err
-		case PubKeyHashTagSecp256k1:
-			encoded, err := Base58CheckEncode(PrefixSecp256k1PublicKeyHash, pubKeyHash)
-			*c = ContractID(encoded)
-			return err
-		case PubKeyHashTagP256:
-			encoded, err := Base58CheckEncode(PrefixP256PublicKeyHash, pubKeyHash)
+		switch ContractIDTag(data[0]) {
+		case ContractIDTagImplicit:
+			if err := publicKeyHash.UnmarshalBinary(data[1:]); err != nil {
+				return err
+			}
+		case ContractIDTagOriginated:
+			contractHash := data[1 : 1+ContractHashLen]
+			encoded, err := Base58CheckEncode(PrefixContractHash, contractHash)
 			*c = ContractID(encoded)
 			return err
 		default:
-			return xerrors.Errorf("unexpected pub_key_hash tag %d", pubKeyHashTag)
+			return xerrors.Errorf("unexpected contract ID tag %d", data[0])
 		}
-	case ContractIDTagOriginated:
-		contractHash := data[1 : 1+ContractHashLen]
-		encoded, err := Base58CheckEncode(PrefixContractHash, contractHash)
-		*c = ContractID(encoded)
-		return err
+	case TaggedPubKeyHashLen:
+		if err := publicKeyHash.UnmarshalBinary(data); err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("expected %d bytes for contract ID or %d bytes for tagged public key hash; received %d: %w", ContractIDLen, TaggedPubKeyHashLen, len(data), ErrUnexpectedLength)
+	}
+	contractID, err := publicKeyHash.ContractID()
+	*c = contractID
+	return err
+}
+
+// String implements fmt.Stringer
+func (c ContractID) String() string {
+	return string(c)
+}
+
+// IsValid returns whether this contract ID is a well-formed implicit or originated
+// address. Other base58check encoded values, such as public keys or block hashes,
+// are not valid contract IDs.
+func (c ContractID) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Validate checks that this contract ID is a well-formed implicit or originated
+// address: its base58check checksum must be valid, its prefix one of the tz1, tz2,
+// tz3 or KT1 prefixes, and its payload of the length that prefix requires.
+func (c ContractID) Validate() error {
+	b58prefix, b58decoded, err := Base58CheckDecode(string(c))
+	if err != nil {
+		return xerrors.Errorf("invalid base58check: %q: %w", c, err)
+	}
+	var expectedLen int
+	switch b58prefix {
+	case PrefixEd25519PublicKeyHash, PrefixSecp256k1PublicKeyHash, PrefixP256PublicKeyHash:
+		expectedLen = PubKeyHashLen
+	case PrefixContractHash:
+		expectedLen = ContractHashLen
 	default:
-		return xerrors.Errorf("unexpected contract ID tag %d", contractIDTag)
+		return xerrors.Errorf("%w %s for contract ID %q", ErrUnexpectedPrefix, b58prefix, c)
+	}
+	if len(b58decoded) != expectedLen {
+		return xerrors.Errorf("expected %d byte payload for contract ID %q, saw %d", expectedLen, c, len(b58decoded))
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. It errors if the contract ID is not
+// a valid implicit or originated address.
+func (c ContractID) MarshalText() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, xerrors.Errorf("invalid contract ID: %w", err)
+	}
+	return []byte(c), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It rejects base58check strings
+// that are not implicit or originated addresses.
+func (c *ContractID) UnmarshalText(data []byte) error {
+	contractID := ContractID(data)
+	if err := contractID.Validate(); err != nil {
+		return xerrors.Errorf("invalid contract ID: %w", err)
 	}
+	*c = contractID
+	return nil
 }
 
 // EncodePubKeyHash returns the public key hash corresponding to this contract
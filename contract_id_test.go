@@ -3,6 +3,7 @@ package tezosprotocol_test
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
@@ -87,6 +88,20 @@ func TestDeriveOriginatedAddress(t *testing.T) {
 	require.Equal(tezosprotocol.ContractID("KT1MXc7s1ZtoVZvbws7vrmz1oLeVGPFoBqpL"), originatedAddr1)
 }
 
+func TestDeriveOriginatedAddresses(t *testing.T) {
+	require := require.New(t)
+	operationHash := tezosprotocol.OperationHash("onwZr5efqY6eT8r7sUf8WAvDKAPQ2qYkyvqP1UAbSoWWeq45Ut5")
+	originatedAddrs, err := tezosprotocol.NewContractIDsFromOrigination(operationHash, 2)
+	require.NoError(err)
+	require.Equal([]tezosprotocol.ContractID{
+		"KT19ZKrg4XVKV9z5zbYav8SonZrGVmxKuRHB",
+		"KT1MXc7s1ZtoVZvbws7vrmz1oLeVGPFoBqpL",
+	}, originatedAddrs)
+	originatedAddrs, err = tezosprotocol.NewContractIDsFromOrigination(operationHash, 0)
+	require.NoError(err)
+	require.Empty(originatedAddrs)
+}
+
 func TestNewContractIDFromPublicKey(t *testing.T) {
 	require := require.New(t)
 	publicKey := tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
@@ -94,6 +109,22 @@ func TestNewContractIDFromPublicKey(t *testing.T) {
 	observed, err := tezosprotocol.NewContractIDFromPublicKey(publicKey)
 	require.NoError(err)
 	require.Equal(expected, observed)
+
+	// secp256k1
+	observed, err = tezosprotocol.NewContractIDFromPublicKey("sppk7czDjVPj1o3hVLeErZTi6brjZNYGc6jFWzFVvW3oRnki3XB58Yq")
+	require.NoError(err)
+	require.Equal(tezosprotocol.ContractID("tz2WKGyvZgv7oJdm3WRQ17o6E6aojQcKcLi1"), observed)
+
+	// P256
+	observed, err = tezosprotocol.NewContractIDFromPublicKey("p2pk653txU6DqbwmfVrpRjs3kWsMfFZD2bZxuDoMbNbu3FQ4s557mHT")
+	require.NoError(err)
+	require.Equal(tezosprotocol.ContractID("tz3RD3Sw9BDqeQs1sh3mTMbB8D3jSd8a5GcN"), observed)
+
+	// keys that aren't points on their curve
+	_, err = tezosprotocol.NewContractIDFromPublicKey("sppk7bFP2oW86SDDFzqiDCMtbm8j4obhJ9AVYkG1XFzwz4ik6kGmM5V")
+	require.Error(err)
+	_, err = tezosprotocol.NewContractIDFromPublicKey("p2pk66WuZc7RC3dPJPEDJVKjhZb2M2MxpY7PwFghDxH48Zz8nivSqHC")
+	require.Error(err)
 }
 
 func TestNewContractIDGeneration(t *testing.T) {
@@ -132,3 +163,57 @@ func TestAccountType(t *testing.T) {
 		require.Equal(testCase.Expected, observedAccountType, "mismatch for input %s", testCase.Input)
 	}
 }
+
+func TestContractIDTextEncoding(t *testing.T) {
+	require := require.New(t)
+
+	// valid addresses
+	for _, input := range []string{
+		"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		"tz29nEixktH9p9XTFX7p8hATUyeLxXEz96KR",
+		"tz3Mo3gHekQhCmykfnC58ecqJLXrjMKzkF2Q",
+		"KT1Q6hx3bJayhQYfMDL1z2ugd7GXGckVAV82",
+	} {
+		contractID := tezosprotocol.ContractID(input)
+		require.True(contractID.IsValid(), input)
+		text, err := contractID.MarshalText()
+		require.NoError(err)
+		require.Equal(input, string(text))
+		var decoded tezosprotocol.ContractID
+		require.NoError(decoded.UnmarshalText([]byte(input)))
+		require.Equal(contractID, decoded)
+	}
+
+	// a public key is valid base58check but not a contract ID
+	publicKey := "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"
+	contractID := tezosprotocol.ContractID(publicKey)
+	require.False(contractID.IsValid())
+	_, err := contractID.MarshalText()
+	require.Error(err)
+	var decoded tezosprotocol.ContractID
+	require.Error(decoded.UnmarshalText([]byte(publicKey)))
+	require.Equal(tezosprotocol.ContractID(""), decoded)
+	err = json.Unmarshal([]byte(`"`+publicKey+`"`), &decoded)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid contract ID")
+
+	// neither is a block hash
+	require.False(tezosprotocol.ContractID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB").IsValid())
+	require.False(tezosprotocol.ContractID("").IsValid())
+}
+
+func TestContractIDValidate(t *testing.T) {
+	require := require.New(t)
+	require.NoError(tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx").Validate())
+	require.NoError(tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq").Validate())
+
+	// bad checksum
+	err := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSy").Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "invalid base58check")
+
+	// not an address prefix
+	err = tezosprotocol.ContractID("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav").Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "unexpected base58check prefix")
+}
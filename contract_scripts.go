@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding"
 	"encoding/binary"
+	"io"
 	"math"
+	"strings"
 
 	"golang.org/x/xerrors"
 )
@@ -17,6 +19,51 @@ type ContractScript struct {
 	Storage []byte
 }
 
+// NewContractScript returns the script with the serialized forms of code and
+// storage
+func NewContractScript(code, storage MichelineNode) (ContractScript, error) {
+	codeBytes, err := code.MarshalBinary()
+	if err != nil {
+		return ContractScript{}, xerrors.Errorf("failed to marshal code: %w", err)
+	}
+	storageBytes, err := storage.MarshalBinary()
+	if err != nil {
+		return ContractScript{}, xerrors.Errorf("failed to marshal storage: %w", err)
+	}
+	return ContractScript{Code: codeBytes, Storage: storageBytes}, nil
+}
+
+// DecodeCode parses the script's code into a Micheline tree
+func (c ContractScript) DecodeCode() (MichelineNode, error) {
+	code, err := decodeMichelineExpression(c.Code)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode code: %w", err)
+	}
+	return code, nil
+}
+
+// DecodeStorage parses the script's storage into a Micheline tree
+func (c ContractScript) DecodeStorage() (MichelineNode, error) {
+	storage, err := decodeMichelineExpression(c.Storage)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode storage: %w", err)
+	}
+	return storage, nil
+}
+
+// decodeMichelineExpression decodes data as a single Micheline node, with no
+// trailing bytes
+func decodeMichelineExpression(data []byte) (MichelineNode, error) {
+	node, bytesRead, err := UnmarshalMicheline(data)
+	if err != nil {
+		return nil, err
+	}
+	if bytesRead != len(data) {
+		return nil, xerrors.Errorf("%d trailing bytes after Micheline expression", len(data)-bytesRead)
+	}
+	return node, nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler. Reference:
 // http://tezos.gitlab.io/mainnet/api/p2p.html#contract-id-22-bytes-8-bit-tag
 func (c ContractScript) MarshalBinary() ([]byte, error) {
@@ -40,8 +87,22 @@ func (c ContractScript) MarshalBinary() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The script must take up
+// all of data.
 func (c *ContractScript) UnmarshalBinary(data []byte) error {
+	bytesRead, err := c.unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	if bytesRead != len(data) {
+		return xerrors.Errorf("expected %d bytes of script, saw %d", bytesRead, len(data))
+	}
+	return nil
+}
+
+// unmarshalBinary decodes the script at the start of data and returns the number
+// of bytes it occupies
+func (c *ContractScript) unmarshalBinary(data []byte) (int, error) {
 	var codeLen uint32
 	var storageLen uint32
 	bytesReader := bytes.NewReader(data)
@@ -49,36 +110,36 @@ func (c *ContractScript) UnmarshalBinary(data []byte) error {
 	// code length
 	err := binary.Read(bytesReader, binary.BigEndian, &codeLen)
 	if err != nil {
-		return xerrors.Errorf("failed to read code length: %w", err)
+		return 0, xerrors.Errorf("failed to read code length: %w", err)
 	}
 
-	// code
+	// code. Check the length first so a bogus length can't cause a huge allocation.
+	if int64(codeLen) > int64(bytesReader.Len()) {
+		return 0, xerrors.Errorf("failed to read code: %d bytes declared but %d remain", codeLen, bytesReader.Len())
+	}
 	c.Code = make([]byte, codeLen)
-	numRead, err := bytesReader.Read(c.Code)
+	_, err = io.ReadFull(bytesReader, c.Code)
 	if err != nil {
-		return xerrors.Errorf("failed to read code: %w", err)
-	}
-	if numRead != int(codeLen) {
-		return xerrors.Errorf("failed to read code")
+		return 0, xerrors.Errorf("failed to read code: %w", err)
 	}
 
 	// storage length
 	err = binary.Read(bytesReader, binary.BigEndian, &storageLen)
 	if err != nil {
-		return xerrors.Errorf("failed to read storage length: %w", err)
+		return 0, xerrors.Errorf("failed to read storage length: %w", err)
 	}
 
-	// storage
+	// storage. Check the length first so a bogus length can't cause a huge allocation.
+	if int64(storageLen) > int64(bytesReader.Len()) {
+		return 0, xerrors.Errorf("failed to read storage: %d bytes declared but %d remain", storageLen, bytesReader.Len())
+	}
 	c.Storage = make([]byte, storageLen)
-	numRead, err = bytesReader.Read(c.Storage)
+	_, err = io.ReadFull(bytesReader, c.Storage)
 	if err != nil {
-		return xerrors.Errorf("failed to read storage: %w", err)
-	}
-	if numRead != int(storageLen) {
-		return xerrors.Errorf("failed to read storage")
+		return 0, xerrors.Errorf("failed to read storage: %w", err)
 	}
 
-	return nil
+	return len(data) - bytesReader.Len(), nil
 }
 
 // EntrypointTag captures the possible tag values for $entrypoint.Tag
@@ -91,7 +152,14 @@ const (
 	EntrypointTagDo             EntrypointTag = 2
 	EntrypointTagSetDelegate    EntrypointTag = 3
 	EntrypointTagRemoveDelegate EntrypointTag = 4
-	EntrypointTagNamed          EntrypointTag = 255
+	EntrypointTagDeposit        EntrypointTag = 5
+	// EntrypointTagStake and the following tags are the pseudo-entrypoints of
+	// implicit accounts added with staking in protocol 018
+	EntrypointTagStake                 EntrypointTag = 6
+	EntrypointTagUnstake               EntrypointTag = 7
+	EntrypointTagFinalizeUnstake       EntrypointTag = 8
+	EntrypointTagSetDelegateParameters EntrypointTag = 9
+	EntrypointTagNamed                 EntrypointTag = 255
 )
 
 // Entrypoint models $entrypoint
@@ -102,22 +170,54 @@ type Entrypoint struct {
 
 // Preset entrypoints (those with an implicit name)
 var (
-	EntrypointDefault        = Entrypoint{tag: EntrypointTagDefault}
-	EntrypointRoot           = Entrypoint{tag: EntrypointTagRoot}
-	EntrypointDo             = Entrypoint{tag: EntrypointTagDo}
-	EntrypointSetDelegate    = Entrypoint{tag: EntrypointTagDefault}
-	EntrypointRemoveDelegate = Entrypoint{tag: EntrypointTagRemoveDelegate}
+	EntrypointDefault               = Entrypoint{tag: EntrypointTagDefault}
+	EntrypointRoot                  = Entrypoint{tag: EntrypointTagRoot}
+	EntrypointDo                    = Entrypoint{tag: EntrypointTagDo}
+	EntrypointSetDelegate           = Entrypoint{tag: EntrypointTagSetDelegate}
+	EntrypointRemoveDelegate        = Entrypoint{tag: EntrypointTagRemoveDelegate}
+	EntrypointDeposit               = Entrypoint{tag: EntrypointTagDeposit}
+	EntrypointStake                 = Entrypoint{tag: EntrypointTagStake}
+	EntrypointUnstake               = Entrypoint{tag: EntrypointTagUnstake}
+	EntrypointFinalizeUnstake       = Entrypoint{tag: EntrypointTagFinalizeUnstake}
+	EntrypointSetDelegateParameters = Entrypoint{tag: EntrypointTagSetDelegateParameters}
 )
 
+// presetEntrypoints lists the entrypoints whose names are encoded by their tag
+var presetEntrypoints = []Entrypoint{
+	EntrypointDefault, EntrypointRoot, EntrypointDo, EntrypointSetDelegate, EntrypointRemoveDelegate,
+	EntrypointDeposit, EntrypointStake, EntrypointUnstake, EntrypointFinalizeUnstake, EntrypointSetDelegateParameters,
+}
+
 // NewNamedEntrypoint creates a named entrypoint. This should be used when attempting to
 // invoke a custom entrypoint that is not one of the reserved ones (%default, %root, %do, etcetera...).
 func NewNamedEntrypoint(name string) (Entrypoint, error) {
+	if name == "" {
+		return Entrypoint{}, xerrors.New("entrypoint name must not be empty")
+	}
 	if len(name) > math.MaxUint8 {
-		return Entrypoint{}, xerrors.Errorf("entrypoint name %s exceeds maximum length %d", math.MaxUint8)
+		return Entrypoint{}, xerrors.Errorf("entrypoint name %s exceeds maximum length %d", name, math.MaxUint8)
 	}
 	return Entrypoint{tag: EntrypointTagNamed, name: name}, nil
 }
 
+// ParseEntrypoint parses the textual form of an entrypoint, as returned by
+// Entrypoint.String. The leading % is optional. Reserved names map to their preset
+// entrypoints and any other name makes a named entrypoint.
+func ParseEntrypoint(s string) (Entrypoint, error) {
+	return entrypointFromName(strings.TrimPrefix(s, "%"))
+}
+
+// entrypointFromName returns the preset entrypoint with the given name, or a named
+// entrypoint if there is none
+func entrypointFromName(name string) (Entrypoint, error) {
+	for _, preset := range presetEntrypoints {
+		if presetName, _ := preset.Name(); presetName == name {
+			return preset, nil
+		}
+	}
+	return NewNamedEntrypoint(name)
+}
+
 // Tag returns the entrypoint tag
 func (e Entrypoint) Tag() EntrypointTag {
 	return e.tag
@@ -136,6 +236,16 @@ func (e Entrypoint) Name() (string, error) {
 		return "set_delegate", nil
 	case EntrypointTagRemoveDelegate:
 		return "remove_delegate", nil
+	case EntrypointTagDeposit:
+		return "deposit", nil
+	case EntrypointTagStake:
+		return "stake", nil
+	case EntrypointTagUnstake:
+		return "unstake", nil
+	case EntrypointTagFinalizeUnstake:
+		return "finalize_unstake", nil
+	case EntrypointTagSetDelegateParameters:
+		return "set_delegate_parameters", nil
 	case EntrypointTagNamed:
 		if e.name == "" {
 			return "", xerrors.Errorf("entrypoint is not named")
@@ -168,40 +278,46 @@ func (e Entrypoint) MarshalBinary() ([]byte, error) { //nolint:golint,unparam
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
 func (e *Entrypoint) UnmarshalBinary(data []byte) error {
+	_, err := e.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the entrypoint at the start of data and returns the number
+// of bytes it occupies
+func (e *Entrypoint) unmarshalBinary(data []byte) (int, error) {
 	if len(data) < 1 {
-		return xerrors.Errorf("too few bytes to unmarshal Entrypoint")
+		return 0, xerrors.Errorf("too few bytes to unmarshal Entrypoint")
 	}
 	e.tag = EntrypointTag(data[0])
-	if e.tag == EntrypointTagNamed {
-		data = data[1:]
-		if len(data) < 1 {
-			return xerrors.Errorf("too few bytes to unmarshal Entrypoint name length")
-		}
-		nameLength := data[0]
-		data = data[1:]
-		if len(data) < int(nameLength) {
-			return xerrors.Errorf("too few bytes to unmarshal Entrypoint name")
-		}
-		e.name = string(data[:nameLength])
+	if e.tag != EntrypointTagNamed {
+		return 1, nil
 	}
-	return nil
+	if len(data) < 2 {
+		return 0, xerrors.Errorf("too few bytes to unmarshal Entrypoint name length")
+	}
+	nameLength := int(data[1])
+	if len(data) < 2+nameLength {
+		return 0, xerrors.Errorf("too few bytes to unmarshal Entrypoint name")
+	}
+	e.name = string(data[2 : 2+nameLength])
+	return 2 + nameLength, nil
 }
 
-// TransactionParametersValue models $X_o.value
+// TransactionParametersValue models $X_o.value. Any MichelineNode can be used as a
+// value; TransactionParameters takes care of length-prefixing its serialized form.
+// Other implementations are written as is and must include their own length prefix.
 type TransactionParametersValue interface {
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
 }
 
-// note: want to create a rich type for this modeling Michelson instructions.
-// This stopgap approach allows just using raw byte arrays in the meantime without
-// sacrificing forward compatibility.
-
-// TransactionParametersValueRawBytes is an interim way to provide the value for
-// transaction parameters, until support for Michelson is added.
+// TransactionParametersValueRawBytes provides the value for transaction parameters
+// as an already serialized Micheline expression. Unlike a MichelineNode, it
+// marshals with its own 4-byte length prefix. Decoded transaction parameters use
+// this type.
 type TransactionParametersValueRawBytes []byte
 
-// MarshalBinary implements encoding.BinaryMarshaler.
+// MarshalBinary implements encoding.BinaryMarshaler. The output is length-prefixed.
 func (t *TransactionParametersValueRawBytes) MarshalBinary() ([]byte, error) {
 	var parameters []byte
 	if t != nil {
@@ -249,12 +365,27 @@ func (t TransactionParameters) MarshalBinary() ([]byte, error) {
 	if err != nil {
 		return nil, xerrors.Errorf("failed to marshal value: %w", err)
 	}
+	if _, ok := t.Value.(MichelineNode); ok {
+		// Micheline nodes marshal as bare expressions and need a length prefix. Other
+		// values, such as raw bytes, are expected to carry their own.
+		err = binary.Write(buffer, binary.BigEndian, uint32(len(valueBytes)))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal value length: %w", err)
+		}
+	}
 	buffer.Write(valueBytes)
 	return buffer.Bytes(), nil
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (t *TransactionParameters) UnmarshalBinary(data []byte) (err error) {
+func (t *TransactionParameters) UnmarshalBinary(data []byte) error {
+	_, err := t.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the parameters at the start of data and returns the number
+// of bytes they occupy
+func (t *TransactionParameters) unmarshalBinary(data []byte) (_ int, err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -264,19 +395,20 @@ func (t *TransactionParameters) UnmarshalBinary(data []byte) (err error) {
 		}
 	}()
 	dataPtr := data
-	err = t.Entrypoint.UnmarshalBinary(dataPtr)
+	bytesRead, err := t.Entrypoint.unmarshalBinary(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal entrypoint: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal entrypoint: %w", err)
 	}
-	entrypointBytes, err := t.Entrypoint.MarshalBinary()
+	dataPtr = dataPtr[bytesRead:]
+	value, err := readLengthPrefixed(dataPtr)
 	if err != nil {
-		return err
+		return 0, xerrors.Errorf("failed to unmarshal value: %w", err)
 	}
-	dataPtr = dataPtr[len(entrypointBytes):]
 	t.Value = &TransactionParametersValueRawBytes{}
-	err = t.Value.UnmarshalBinary(dataPtr)
+	err = t.Value.UnmarshalBinary(dataPtr[:4+len(value)])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal value: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal value: %w", err)
 	}
-	return nil
+	dataPtr = dataPtr[4+len(value):]
+	return len(data) - len(dataPtr), nil
 }
@@ -1,6 +1,7 @@
 package tezosprotocol_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"math"
 	"strings"
@@ -38,6 +39,60 @@ func TestContractScriptUnmarshalBinary(t *testing.T) {
 	err = (&tezosprotocol.ContractScript{}).UnmarshalBinary(badStorage)
 	require.Error(err)
 	require.Contains(err.Error(), "failed to read storage")
+
+	// trailing bytes
+	trailingGarbage, err := hex.DecodeString("00000002C0DE00000002C0DEFF")
+	require.NoError(err)
+	err = (&tezosprotocol.ContractScript{}).UnmarshalBinary(trailingGarbage)
+	require.Error(err)
+	require.Contains(err.Error(), "expected 12 bytes of script, saw 13")
+	require.NoError((&tezosprotocol.ContractScript{}).UnmarshalBinary(trailingGarbage[:12]))
+}
+
+func TestContractScriptLargeCode(t *testing.T) {
+	require := require.New(t)
+	script := tezosprotocol.ContractScript{
+		Code:    bytes.Repeat([]byte{0xc0, 0xde}, 64*1024),
+		Storage: bytes.Repeat([]byte{0x5e}, 16*1024),
+	}
+	encoded, err := script.MarshalBinary()
+	require.NoError(err)
+	decoded := tezosprotocol.ContractScript{}
+	require.NoError(decoded.UnmarshalBinary(encoded))
+	require.Equal(script, decoded)
+}
+
+func TestContractScriptMicheline(t *testing.T) {
+	require := require.New(t)
+	prim := func(prim byte, args ...tezosprotocol.MichelineNode) *tezosprotocol.MichelinePrim {
+		return &tezosprotocol.MichelinePrim{Prim: prim, Args: args}
+	}
+	// parameter unit; storage unit; code { CDR; NIL operation; PAIR }
+	code := &tezosprotocol.MichelineSeq{
+		prim(tezosprotocol.PrimK_parameter, prim(tezosprotocol.PrimT_unit)),
+		prim(tezosprotocol.PrimK_storage, prim(tezosprotocol.PrimT_unit)),
+		prim(tezosprotocol.PrimK_code, &tezosprotocol.MichelineSeq{
+			prim(tezosprotocol.PrimI_CDR),
+			prim(tezosprotocol.PrimI_NIL, prim(tezosprotocol.PrimT_operation)),
+			prim(tezosprotocol.PrimI_PAIR),
+		}),
+	}
+	storage := prim(tezosprotocol.PrimD_Unit)
+	script, err := tezosprotocol.NewContractScript(code, storage)
+	require.NoError(err)
+	require.Equal("030b", hex.EncodeToString(script.Storage))
+
+	decodedCode, err := script.DecodeCode()
+	require.NoError(err)
+	require.Equal(code, decodedCode)
+	decodedStorage, err := script.DecodeStorage()
+	require.NoError(err)
+	require.Equal(storage, decodedStorage)
+
+	// trailing bytes
+	script.Storage = append(script.Storage, 0)
+	_, err = script.DecodeStorage()
+	require.Error(err)
 }
 
 func TestSerializeTransactionParameters(t *testing.T) {
@@ -72,6 +127,61 @@ func TestSerializeTransactionParameters(t *testing.T) {
 	require.Equal(params, reserialized)
 }
 
+func TestSerializeMichelineTransactionParameters(t *testing.T) {
+	require := require.New(t)
+
+	// {"entrypoint": "default", "value": {"prim": "Unit"}}
+	params := tezosprotocol.TransactionParameters{
+		Entrypoint: tezosprotocol.EntrypointDefault,
+		Value:      &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit},
+	}
+	observedBytes, err := params.MarshalBinary()
+	require.NoError(err)
+	require.Equal("0000000002030b", hex.EncodeToString(observedBytes))
+
+	// decoding yields the equivalent raw bytes value
+	reserialized := tezosprotocol.TransactionParameters{}
+	require.NoError(reserialized.UnmarshalBinary(observedBytes))
+	require.Equal(tezosprotocol.EntrypointDefault, reserialized.Entrypoint)
+	require.Equal(tezosprotocol.TransactionParametersValueRawBytes{0x03, 0x0b}, *reserialized.Value.(*tezosprotocol.TransactionParametersValueRawBytes))
+
+	// {"entrypoint": "do", "value": {"string": "a"}}
+	value := tezosprotocol.MichelineString("a")
+	params = tezosprotocol.TransactionParameters{
+		Entrypoint: tezosprotocol.EntrypointDo,
+		Value:      &value,
+	}
+	observedBytes, err = params.MarshalBinary()
+	require.NoError(err)
+	require.Equal("0200000006010000000161", hex.EncodeToString(observedBytes))
+}
+
+// prefixedValue is a TransactionParametersValue that is not a MichelineNode and
+// serializes with its own length prefix
+type prefixedValue []byte
+
+func (v prefixedValue) MarshalBinary() ([]byte, error) {
+	return append([]byte{0, 0, 0, byte(len(v))}, v...), nil
+}
+
+func (v *prefixedValue) UnmarshalBinary(data []byte) error {
+	*v = append(prefixedValue{}, data[4:]...)
+	return nil
+}
+
+func TestSerializeCustomTransactionParameters(t *testing.T) {
+	require := require.New(t)
+
+	// values other than Micheline nodes are written without an extra length prefix
+	params := tezosprotocol.TransactionParameters{
+		Entrypoint: tezosprotocol.EntrypointDo,
+		Value:      &prefixedValue{0x03, 0x0b},
+	}
+	observedBytes, err := params.MarshalBinary()
+	require.NoError(err)
+	require.Equal("0200000002030b", hex.EncodeToString(observedBytes))
+}
+
 func TestSerializeNamedEntrypoint(t *testing.T) {
 	require := require.New(t)
 
@@ -106,9 +216,35 @@ func TestSerializeNamedEntrypoint(t *testing.T) {
 	require.Equal(params, reserialized)
 }
 
+func TestMarshalPresetEntrypoints(t *testing.T) {
+	require := require.New(t)
+	for entrypoint, tag := range map[tezosprotocol.Entrypoint]byte{
+		tezosprotocol.EntrypointDefault:               0,
+		tezosprotocol.EntrypointRoot:                  1,
+		tezosprotocol.EntrypointDo:                    2,
+		tezosprotocol.EntrypointSetDelegate:           3,
+		tezosprotocol.EntrypointRemoveDelegate:        4,
+		tezosprotocol.EntrypointDeposit:               5,
+		tezosprotocol.EntrypointStake:                 6,
+		tezosprotocol.EntrypointUnstake:               7,
+		tezosprotocol.EntrypointFinalizeUnstake:       8,
+		tezosprotocol.EntrypointSetDelegateParameters: 9,
+	} {
+		encoded, err := entrypoint.MarshalBinary()
+		require.NoError(err)
+		require.Equal([]byte{tag}, encoded, "%s", entrypoint)
+	}
+}
+
 func TestEndpointNameTooLong(t *testing.T) {
 	_, err := tezosprotocol.NewNamedEntrypoint(strings.Repeat("a", math.MaxUint8+1))
 	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds maximum length 255")
+}
+
+func TestEndpointNameEmpty(t *testing.T) {
+	_, err := tezosprotocol.NewNamedEntrypoint("")
+	require.Error(t, err)
 }
 
 func TestEntrypoint_Name(t *testing.T) {
@@ -148,6 +284,36 @@ func TestEntrypoint_Name(t *testing.T) {
 			want:    "remove_delegate",
 			wantErr: false,
 		},
+		{
+			name:    "deposit",
+			bytes:   []byte{byte(tezosprotocol.EntrypointTagDeposit)},
+			want:    "deposit",
+			wantErr: false,
+		},
+		{
+			name:    "stake",
+			bytes:   []byte{byte(tezosprotocol.EntrypointTagStake)},
+			want:    "stake",
+			wantErr: false,
+		},
+		{
+			name:    "unstake",
+			bytes:   []byte{byte(tezosprotocol.EntrypointTagUnstake)},
+			want:    "unstake",
+			wantErr: false,
+		},
+		{
+			name:    "finalize_unstake",
+			bytes:   []byte{byte(tezosprotocol.EntrypointTagFinalizeUnstake)},
+			want:    "finalize_unstake",
+			wantErr: false,
+		},
+		{
+			name:    "set_delegate_parameters",
+			bytes:   []byte{byte(tezosprotocol.EntrypointTagSetDelegateParameters)},
+			want:    "set_delegate_parameters",
+			wantErr: false,
+		},
 		{
 			name:    "named",
 			bytes:   append([]byte{byte(tezosprotocol.EntrypointTagNamed), 4}, []byte("tada")...),
@@ -219,6 +385,31 @@ func TestEntrypoint_Tag(t *testing.T) {
 			bytes: []byte{byte(tezosprotocol.EntrypointTagRemoveDelegate)},
 			want:  tezosprotocol.EntrypointTagRemoveDelegate,
 		},
+		{
+			name:  "deposit",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagDeposit)},
+			want:  tezosprotocol.EntrypointTagDeposit,
+		},
+		{
+			name:  "stake",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagStake)},
+			want:  tezosprotocol.EntrypointTagStake,
+		},
+		{
+			name:  "unstake",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagUnstake)},
+			want:  tezosprotocol.EntrypointTagUnstake,
+		},
+		{
+			name:  "finalize_unstake",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagFinalizeUnstake)},
+			want:  tezosprotocol.EntrypointTagFinalizeUnstake,
+		},
+		{
+			name:  "set_delegate_parameters",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagSetDelegateParameters)},
+			want:  tezosprotocol.EntrypointTagSetDelegateParameters,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -267,6 +458,31 @@ func TestEntrypoint_String(t *testing.T) {
 			bytes: []byte{byte(tezosprotocol.EntrypointTagRemoveDelegate)},
 			want:  "remove_delegate",
 		},
+		{
+			name:  "deposit",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagDeposit)},
+			want:  "deposit",
+		},
+		{
+			name:  "stake",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagStake)},
+			want:  "stake",
+		},
+		{
+			name:  "unstake",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagUnstake)},
+			want:  "unstake",
+		},
+		{
+			name:  "finalize_unstake",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagFinalizeUnstake)},
+			want:  "finalize_unstake",
+		},
+		{
+			name:  "set_delegate_parameters",
+			bytes: []byte{byte(tezosprotocol.EntrypointTagSetDelegateParameters)},
+			want:  "set_delegate_parameters",
+		},
 		{
 			name:  "named",
 			bytes: append([]byte{byte(tezosprotocol.EntrypointTagNamed), 4}, []byte("tada")...),
@@ -304,3 +520,38 @@ func TestEntrypoint_String(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEntrypoint(t *testing.T) {
+	require := require.New(t)
+	for _, entrypoint := range []tezosprotocol.Entrypoint{
+		tezosprotocol.EntrypointDefault,
+		tezosprotocol.EntrypointRoot,
+		tezosprotocol.EntrypointDo,
+		tezosprotocol.EntrypointSetDelegate,
+		tezosprotocol.EntrypointRemoveDelegate,
+		tezosprotocol.EntrypointDeposit,
+		tezosprotocol.EntrypointStake,
+		tezosprotocol.EntrypointUnstake,
+		tezosprotocol.EntrypointFinalizeUnstake,
+		tezosprotocol.EntrypointSetDelegateParameters,
+	} {
+		parsed, err := tezosprotocol.ParseEntrypoint(entrypoint.String())
+		require.NoError(err)
+		require.Equal(entrypoint, parsed)
+	}
+
+	named, err := tezosprotocol.NewNamedEntrypoint("transfer")
+	require.NoError(err)
+	parsed, err := tezosprotocol.ParseEntrypoint("%transfer")
+	require.NoError(err)
+	require.Equal(named, parsed)
+	parsed, err = tezosprotocol.ParseEntrypoint("transfer")
+	require.NoError(err)
+	require.Equal(named, parsed)
+	parsed, err = tezosprotocol.ParseEntrypoint("set_delegate")
+	require.NoError(err)
+	require.Equal(tezosprotocol.EntrypointSetDelegate, parsed)
+
+	_, err = tezosprotocol.ParseEntrypoint("%")
+	require.Error(err)
+}
@@ -19,6 +19,19 @@ type Delegation struct {
 	Delegate     *ContractID
 }
 
+// NewDelegation returns a delegation of source to delegate. A nil delegate
+// withdraws the current delegation. The fee is zero and should be filled in after
+// estimation, and the counter is left unset.
+func NewDelegation(source ContractID, delegate *ContractID) *Delegation {
+	return &Delegation{
+		Source:       source,
+		Fee:          big.NewInt(0),
+		GasLimit:     big.NewInt(DelegationGasLimit),
+		StorageLimit: big.NewInt(DelegationStorageLimitBytes),
+		Delegate:     delegate,
+	}
+}
+
 func (d *Delegation) String() string {
 	return fmt.Sprintf("%#v", d)
 }
@@ -33,6 +46,12 @@ func (d *Delegation) GetSource() ContractID {
 	return d.Source
 }
 
+// FeeMutez returns the fee as an int64 number of mutez. It errors if the fee
+// does not fit in an int64.
+func (d *Delegation) FeeMutez() (int64, error) {
+	return mutezToInt64("fee", d.Fee)
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler
 func (d *Delegation) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -90,7 +109,14 @@ func (d *Delegation) MarshalBinary() ([]byte, error) {
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler
-func (d *Delegation) UnmarshalBinary(data []byte) (err error) {
+func (d *Delegation) UnmarshalBinary(data []byte) error {
+	_, err := d.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the delegation at the start of data and returns the number
+// of bytes it occupies
+func (d *Delegation) unmarshalBinary(data []byte) (_ int, err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -105,14 +131,14 @@ func (d *Delegation) UnmarshalBinary(data []byte) (err error) {
 	// tag
 	tag := ContentsTag(dataPtr[0])
 	if tag != ContentsTagDelegation {
-		return xerrors.Errorf("invalid tag for delegation. Expected %d, saw %d", ContentsTagDelegation, tag)
+		return 0, xerrors.Errorf("invalid tag for delegation. Expected %d, saw %d", ContentsTagDelegation, tag)
 	}
 	dataPtr = dataPtr[1:]
 
 	// source
 	err = d.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal source: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
 	}
 	dataPtr = dataPtr[TaggedPubKeyHashLen:]
 
@@ -120,35 +146,35 @@ func (d *Delegation) UnmarshalBinary(data []byte) (err error) {
 	var bytesRead int
 	d.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal fee: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// counter
 	d.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal counter: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// gas limit
 	d.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// storage limit
 	d.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// delegate
 	hasDelegate, err := deserializeBoolean(dataPtr[0])
 	if err != nil {
-		return xerrors.Errorf("failed to deserialize presence of field \"delegate\": %w", err)
+		return 0, xerrors.Errorf("failed to deserialize presence of field \"delegate\": %w", err)
 	}
 	dataPtr = dataPtr[1:]
 	if hasDelegate {
@@ -156,10 +182,11 @@ func (d *Delegation) UnmarshalBinary(data []byte) (err error) {
 		var delegate ContractID
 		err = delegate.UnmarshalBinary(taggedPubKeyHash)
 		if err != nil {
-			return xerrors.Errorf("failed to deserialize delegate: %w", err)
+			return 0, xerrors.Errorf("failed to deserialize delegate: %w", err)
 		}
 		d.Delegate = &delegate
+		dataPtr = dataPtr[TaggedPubKeyHashLen:]
 	}
 
-	return nil
+	return len(data) - len(dataPtr), nil
 }
@@ -41,3 +41,14 @@ func TestDecodeDelegation(t *testing.T) {
 	require.NotNil(delegation.Delegate)
 	require.Equal(tezosprotocol.ContractID("tz1ddb9NMYHZi5UzPdzTZMYQQZoMub195zgv"), *delegation.Delegate)
 }
+
+func TestNewDelegation(t *testing.T) {
+	require := require.New(t)
+	delegate := tezosprotocol.ContractID("tz1ddb9NMYHZi5UzPdzTZMYQQZoMub195zgv")
+	delegation := tezosprotocol.NewDelegation("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", &delegate)
+	require.Equal(&delegate, delegation.Delegate)
+	require.Equal("0", delegation.Fee.String())
+	require.Nil(delegation.Counter)
+	require.Equal(tezosprotocol.DelegationGasLimit, delegation.GasLimit.Int64())
+	require.Equal(tezosprotocol.DelegationStorageLimitBytes, delegation.StorageLimit.Int64())
+}
@@ -0,0 +1,93 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// DoubleBakingEvidence models the tezos double_baking_evidence operation type, with
+// which an accuser denounces a baker that signed two different blocks at the same
+// level. Both headers are complete: their ProtocolData ends with the baker's
+// signature.
+type DoubleBakingEvidence struct {
+	BlockHeader1 BlockHeader
+	BlockHeader2 BlockHeader
+}
+
+func (d *DoubleBakingEvidence) String() string {
+	return fmt.Sprintf("%#v", d)
+}
+
+// GetTag implements OperationContents
+func (d *DoubleBakingEvidence) GetTag() ContentsTag {
+	return ContentsTagDoubleBakingEvidence
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (d *DoubleBakingEvidence) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(d.GetTag()))
+
+	// block headers, each prefixed with its length
+	for i, header := range []*BlockHeader{&d.BlockHeader1, &d.BlockHeader2} {
+		headerBytes, err := header.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write block header %d: %w", i+1, err)
+		}
+		err = binary.Write(&buf, binary.BigEndian, uint32(len(headerBytes)))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write block header %d length: %w", i+1, err)
+		}
+		buf.Write(headerBytes)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (d *DoubleBakingEvidence) UnmarshalBinary(data []byte) error {
+	_, err := d.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the double baking evidence at the start of data and returns
+// the number of bytes it occupies
+func (d *DoubleBakingEvidence) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagDoubleBakingEvidence {
+		return 0, xerrors.Errorf("invalid tag for double baking evidence. Expected %d, saw %d", ContentsTagDoubleBakingEvidence, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// block headers
+	for i, header := range []*BlockHeader{&d.BlockHeader1, &d.BlockHeader2} {
+		headerBytes, err := readLengthPrefixed(dataPtr)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal block header %d: %w", i+1, err)
+		}
+		err = header.UnmarshalBinary(headerBytes)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal block header %d: %w", i+1, err)
+		}
+		dataPtr = dataPtr[4+len(headerBytes):]
+	}
+
+	return len(data) - len(dataPtr), nil
+}
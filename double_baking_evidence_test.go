@@ -0,0 +1,49 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleBakingEvidence(t *testing.T) {
+	require := require.New(t)
+	// two headers at the same level that differ only in their protocol data
+	headerHex := func(protocolData string) string {
+		return "00000001" + "02" +
+			"e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f" +
+			"000000005f5e1000" + "04" +
+			"1111111111111111111111111111111111111111111111111111111111111111" +
+			"0000000d" + "0000000101" + "0000000400000005" +
+			"2222222222222222222222222222222222222222222222222222222222222222" +
+			protocolData
+	}
+	encodedHex := "03" + "00000081" + headerHex("dead") + "00000081" + headerHex("beef")
+	encoded, err := hex.DecodeString(encodedHex)
+	require.NoError(err)
+
+	branch := fromHex("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f")
+	operation := tezosprotocol.Operation{}
+	require.NoError(operation.UnmarshalBinary(append(branch, encoded...)))
+	require.Len(operation.Contents, 1)
+	evidence, ok := operation.Contents[0].(*tezosprotocol.DoubleBakingEvidence)
+	require.True(ok)
+	for _, header := range []tezosprotocol.BlockHeader{evidence.BlockHeader1, evidence.BlockHeader2} {
+		require.Equal(int32(1), header.Level)
+		require.Equal(tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"), header.Predecessor)
+		require.Equal([][]byte{{0x01}, {0x00, 0x00, 0x00, 0x05}}, header.Fitness)
+	}
+	require.Equal([]byte{0xde, 0xad}, evidence.BlockHeader1.ProtocolData)
+	require.Equal([]byte{0xbe, 0xef}, evidence.BlockHeader2.ProtocolData)
+
+	reencoded, err := evidence.MarshalBinary()
+	require.NoError(err)
+	require.Equal(encodedHex, hex.EncodeToString(reencoded))
+
+	// a header length running past the evidence
+	require.Error(evidence.UnmarshalBinary(encoded[:len(encoded)-1]))
+	// a header too short to hold a shell header
+	require.Error(evidence.UnmarshalBinary(fromHex("03" + "00000002" + "0000" + "00000081" + headerHex("beef"))))
+}
@@ -0,0 +1,97 @@
+package tezosprotocol
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/xerrors"
+)
+
+// Encrypted secret key parameters, as used by octez-client.
+// An encrypted secret key is an 8-byte salt followed by the key material sealed
+// with NaCl secretbox, under a zero nonce and a key derived from the passphrase
+// and salt with PBKDF2-HMAC-SHA512.
+const (
+	encryptedKeySaltLen    = 8
+	encryptedKeyIterations = 32768
+	encryptedKeyLen        = 32
+)
+
+// DecryptPrivateKey decrypts an encrypted secret key (edesk, spesk or p2esk), such
+// as those stored by octez-client, and returns the corresponding PrivateKey.
+func DecryptPrivateKey(encrypted string, passphrase []byte) (PrivateKey, error) {
+	b58prefix, payload, err := Base58CheckDecode(encrypted)
+	if err != nil {
+		return "", xerrors.Errorf("failed to base58check decode encrypted key: %w", err)
+	}
+	switch b58prefix {
+	case PrefixEd25519EncryptedSeed, PrefixSecp256k1EncryptedSecretKey, PrefixP256EncryptedSecretKey:
+	default:
+		return "", xerrors.Errorf("unexpected base58check encrypted key prefix %s", b58prefix)
+	}
+	salt, box := payload[:encryptedKeySaltLen], payload[encryptedKeySaltLen:]
+	var nonce [24]byte
+	secretKey := encryptedKeySecretboxKey(passphrase, salt)
+	keyMaterial, ok := secretbox.Open(nil, box, &nonce, &secretKey)
+	if !ok {
+		return "", xerrors.New("failed to decrypt key: wrong passphrase or corrupted key")
+	}
+	switch b58prefix {
+	case PrefixEd25519EncryptedSeed:
+		return NewPrivateKeyFromCryptoPrivateKey(ed25519.NewKeyFromSeed(keyMaterial))
+	case PrefixSecp256k1EncryptedSecretKey:
+		privateKey, err := Base58CheckEncode(PrefixSecp256k1SecretKey, keyMaterial)
+		return PrivateKey(privateKey), err
+	default:
+		privateKey, err := Base58CheckEncode(PrefixP256SecretKey, keyMaterial)
+		return PrivateKey(privateKey), err
+	}
+}
+
+// Encrypt encrypts the private key under passphrase with a random salt, in the
+// encrypted secret key format (edesk, spesk or p2esk) used by octez-client. It can
+// be decrypted with DecryptPrivateKey.
+func (p PrivateKey) Encrypt(passphrase []byte) (string, error) {
+	b58prefix, keyBytes, err := Base58CheckDecode(string(p))
+	if err != nil {
+		return "", xerrors.New("unable to base58check decode private key")
+	}
+	var encryptedPrefix Base58CheckPrefix
+	var keyMaterial []byte
+	switch b58prefix {
+	case PrefixEd25519SecretKey:
+		// ed25519 keys are stored as their seed
+		encryptedPrefix = PrefixEd25519EncryptedSeed
+		keyMaterial = ed25519.PrivateKey(keyBytes).Seed()
+	case PrefixSecp256k1SecretKey:
+		encryptedPrefix = PrefixSecp256k1EncryptedSecretKey
+		keyMaterial = keyBytes
+	case PrefixP256SecretKey:
+		encryptedPrefix = PrefixP256EncryptedSecretKey
+		keyMaterial = keyBytes
+	default:
+		return "", xerrors.Errorf("unexpected base58check private key prefix %s", b58prefix)
+	}
+
+	salt := make([]byte, encryptedKeySaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", xerrors.Errorf("failed to generate salt: %w", err)
+	}
+	var nonce [24]byte
+	secretKey := encryptedKeySecretboxKey(passphrase, salt)
+	encrypted, err := Base58CheckEncode(encryptedPrefix, secretbox.Seal(salt, keyMaterial, &nonce, &secretKey))
+	if err != nil {
+		return "", xerrors.Errorf("failed to encode encrypted key: %w", err)
+	}
+	return encrypted, nil
+}
+
+// encryptedKeySecretboxKey derives the secretbox key for an encrypted secret key
+func encryptedKeySecretboxKey(passphrase []byte, salt []byte) [encryptedKeyLen]byte {
+	var key [encryptedKeyLen]byte
+	copy(key[:], pbkdf2.Key(passphrase, salt, encryptedKeyIterations, encryptedKeyLen, sha512.New))
+	return key
+}
@@ -0,0 +1,57 @@
+package tezosprotocol_test
+
+import (
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecryptPrivateKey(t *testing.T) {
+	require := require.New(t)
+	ed25519Key, err := tezosprotocol.PrivateKeySeed("edsk2iznZUCwZBGHuGLcEK3ax3PfCZjNEhtmqqzChsoYRx3mJNB266").PrivateKey()
+	require.NoError(err)
+	tests := []struct {
+		encrypted string
+		expected  tezosprotocol.PrivateKey
+	}{
+		{"edesk1GBQ31Tu4Cfg8UVxcg8CmW1ifhVu4jXC7GAFKPQdpXDMtWR19jpqP9YrDWJfxKFYtzFuyNapQyPvs2Sxr5S", ed25519Key},
+		{"spesk1RzvxguVHK8jaauiJU6WtK6CYDhnXNFpwpjV2KfEyMemNyEaQonYG9urAfo7TpNaYswbiwGhrwK5g7vvGPK", "spsk1VYUZ55WyqNxN7nMbgzE8t7av5CGiAEbbESY3KpKJYEoAzZzmv"},
+		{"p2esk1kpZnyokqFG3RtW2fV42m5mQqY2NpYgkBUpH8XunUwvS9emWSxXaFPVR87SEgnnz2hnoE2htCvx4CR2edEp", "p2sk2RetUFZCNQT2yXYfWbeF5hTcj8WrMzzuTpBXiXZHDSVrcFpdmo"},
+	}
+	for _, tt := range tests {
+		privateKey, err := tezosprotocol.DecryptPrivateKey(tt.encrypted, []byte("foobar"))
+		require.NoError(err, tt.encrypted)
+		require.Equal(tt.expected, privateKey)
+
+		_, err = tezosprotocol.DecryptPrivateKey(tt.encrypted, []byte("wrong"))
+		require.Error(err)
+	}
+
+	_, err = tezosprotocol.DecryptPrivateKey("edsk2iznZUCwZBGHuGLcEK3ax3PfCZjNEhtmqqzChsoYRx3mJNB266", []byte("foobar"))
+	require.Error(err)
+}
+
+func TestEncryptPrivateKey(t *testing.T) {
+	require := require.New(t)
+	ed25519Key, err := tezosprotocol.PrivateKeySeed("edsk2iznZUCwZBGHuGLcEK3ax3PfCZjNEhtmqqzChsoYRx3mJNB266").PrivateKey()
+	require.NoError(err)
+	for _, privateKey := range []tezosprotocol.PrivateKey{
+		ed25519Key,
+		"spsk1VYUZ55WyqNxN7nMbgzE8t7av5CGiAEbbESY3KpKJYEoAzZzmv",
+		"p2sk2RetUFZCNQT2yXYfWbeF5hTcj8WrMzzuTpBXiXZHDSVrcFpdmo",
+	} {
+		encrypted, err := privateKey.Encrypt([]byte("foobar"))
+		require.NoError(err)
+		require.Equal(string(privateKey)[:2], encrypted[:2])
+		require.Equal("esk", encrypted[2:5])
+		decrypted, err := tezosprotocol.DecryptPrivateKey(encrypted, []byte("foobar"))
+		require.NoError(err)
+		require.Equal(privateKey, decrypted)
+
+		// salts are random
+		encryptedAgain, err := privateKey.Encrypt([]byte("foobar"))
+		require.NoError(err)
+		require.NotEqual(encrypted, encryptedAgain)
+	}
+}
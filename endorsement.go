@@ -8,7 +8,9 @@ import (
 	"golang.org/x/xerrors"
 )
 
-// Endorsement models the tezos endorsement operation type
+// Endorsement models the tezos endorsement operation type. Endorsements are
+// consensus operations: they are signed with the endorsement watermark and can't be
+// batched with manager operations in the same Operation.
 type Endorsement struct {
 	Level int32
 }
@@ -22,6 +24,13 @@ func (e *Endorsement) GetTag() ContentsTag {
 	return ContentsTagEndorsement
 }
 
+// GetSource returns the operation's source. Endorsements carry no source on the
+// wire, since the endorser is identified by the key that signs them, so this is
+// always empty.
+func (e *Endorsement) GetSource() ContractID {
+	return ""
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler
 func (e *Endorsement) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -50,7 +59,14 @@ func readInt32(data []byte) (ret int32, err error) {
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler
-func (e *Endorsement) UnmarshalBinary(data []byte) (err error) {
+func (e *Endorsement) UnmarshalBinary(data []byte) error {
+	_, err := e.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the endorsement at the start of data and returns the number
+// of bytes it occupies
+func (e *Endorsement) unmarshalBinary(data []byte) (_ int, err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -65,16 +81,34 @@ func (e *Endorsement) UnmarshalBinary(data []byte) (err error) {
 	// tag
 	tag := ContentsTag(dataPtr[0])
 	if tag != ContentsTagEndorsement {
-		return xerrors.Errorf("invalid tag for endorsement. Expected %d, saw %d", ContentsTagEndorsement, tag)
+		return 0, xerrors.Errorf("invalid tag for endorsement. Expected %d, saw %d", ContentsTagEndorsement, tag)
 	}
 	dataPtr = dataPtr[1:]
 
 	// Level
-	level, err := readInt32(dataPtr)
+	level, err := readInt32(dataPtr[:4])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal level: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal level: %w", err)
 	}
 	e.Level = level
+	dataPtr = dataPtr[4:]
+
+	return len(data) - len(dataPtr), nil
+}
 
-	return nil
+// SignEndorsement signs an operation made of the endorsement e on top of branch.
+// Endorsements are signed with EndorsementWatermark followed by the chain ID, which
+// keeps an endorsement for one chain from being replayed on another. The endorsed
+// branch is part of the signed operation, so it must be given along with e.
+func SignEndorsement(e *Endorsement, branch BranchID, chainID ChainID, key PrivateKey) (Signature, error) {
+	chainIDBytes, err := chainID.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("invalid chain ID: %w", err)
+	}
+	operation := &Operation{Branch: branch, Contents: []OperationContents{e}}
+	operationBytes, err := operation.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("failed to marshal endorsement: %s: %w", e, err)
+	}
+	return signGeneric(EndorsementWatermark, append(chainIDBytes, operationBytes...), key)
 }
@@ -28,3 +28,31 @@ func TestDecodeEndorsement(t *testing.T) {
 	require.NoError(endorsement.UnmarshalBinary(encoded))
 	require.Equal(int32(999), endorsement.Level)
 }
+
+func TestSignEndorsement(t *testing.T) {
+	require := require.New(t)
+	endorsement := &tezosprotocol.Endorsement{Level: 450000}
+	branch := tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB")
+	privateKey := tezosprotocol.PrivateKey("edskRc9Pr1NKUW9x6kAZb9cFerBWMo9X9dW4fXwzzL2rvKyKPfdJaJVUcYCfR37sbBujAXJXVJZoCXsUHzfhNcWuqy9aGunQPk")
+	publicKey, err := privateKey.PublicKey()
+	require.NoError(err)
+	cryptoPublicKey, err := publicKey.CryptoPublicKey()
+	require.NoError(err)
+
+	signature, err := tezosprotocol.SignEndorsement(endorsement, branch, tezosprotocol.ChainID("NetXdQprcVkpaWU"), privateKey)
+	require.NoError(err)
+	// watermark, chain ID, branch, endorsement
+	message, err := hex.DecodeString("7a06a770" + "e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f" + "000006ddd0")
+	require.NoError(err)
+	ok, err := signature.Verify(tezosprotocol.EndorsementWatermark, message, cryptoPublicKey)
+	require.NoError(err)
+	require.True(ok)
+
+	// the signature is bound to the chain
+	otherChainSignature, err := tezosprotocol.SignEndorsement(endorsement, branch, tezosprotocol.ChainID("NetXjD3HPJJjmcd"), privateKey)
+	require.NoError(err)
+	require.NotEqual(signature, otherChainSignature)
+
+	_, err = tezosprotocol.SignEndorsement(endorsement, branch, tezosprotocol.ChainID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"), privateKey)
+	require.Error(err)
+}
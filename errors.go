@@ -0,0 +1,19 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// Errors that callers can test for with errors.Is. Errors returned by this package
+// wrap them where they apply, along with details of the failure.
+var (
+	// ErrInvalidChecksum indicates a base58check string whose checksum does not match
+	ErrInvalidChecksum = xerrors.New("b58check checksum failed")
+	// ErrUnknownPrefix indicates a base58check string whose prefix is not registered
+	ErrUnknownPrefix = xerrors.New("unknown base58check prefix")
+	// ErrUnexpectedPrefix indicates a valid base58check string of the wrong type, such
+	// as a public key where an address was expected
+	ErrUnexpectedPrefix = xerrors.New("unexpected base58check prefix")
+	// ErrUnexpectedLength indicates a value, encoded or decoded, of the wrong length
+	ErrUnexpectedLength = xerrors.New("unexpected length")
+	// ErrOutOfBounds indicates binary input that ended before the value it encodes
+	ErrOutOfBounds = xerrors.New("out of bounds exception while parsing operation")
+)
@@ -0,0 +1,83 @@
+package tezosprotocol_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := tezosprotocol.Base58CheckDecode("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSR")
+	require.True(errors.Is(err, tezosprotocol.ErrInvalidChecksum), err)
+
+	_, _, err = tezosprotocol.Base58CheckDecode("zz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LoDpVc2")
+	require.True(errors.Is(err, tezosprotocol.ErrUnknownPrefix), err)
+
+	_, _, err = tezosprotocol.Base58CheckDecode("8Fy8oBr77jCfuUas")
+	require.True(errors.Is(err, tezosprotocol.ErrUnexpectedLength), err)
+
+	// errors keep their sentinel through the package's own wrapping
+	_, err = tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSR").AccountType()
+	require.True(errors.Is(err, tezosprotocol.ErrInvalidChecksum), err)
+
+	err = tezosprotocol.ContractID("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav").Validate()
+	require.True(errors.Is(err, tezosprotocol.ErrUnexpectedPrefix), err)
+
+	var branch tezosprotocol.BranchID
+	err = branch.UnmarshalBinary([]byte{1, 2, 3})
+	require.True(errors.Is(err, tezosprotocol.ErrUnexpectedLength), err)
+
+	err = (&tezosprotocol.Transaction{}).UnmarshalBinary([]byte{108})
+	require.True(errors.Is(err, tezosprotocol.ErrOutOfBounds), err)
+	err = (&tezosprotocol.Operation{}).UnmarshalBinary(make([]byte, 10))
+	require.True(errors.Is(err, tezosprotocol.ErrOutOfBounds), err)
+}
+
+// recoverDecodeError runs parse and recovers from its panics the way decoders do
+func recoverDecodeError(parse func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = tezosprotocol.CatchOutOfRangeExceptions(r)
+		}
+	}()
+	parse()
+	return nil
+}
+
+func TestCatchOutOfRangeExceptions(t *testing.T) {
+	require := require.New(t)
+	data := make([]byte, 2, 20)
+	n, m := 5, 33
+
+	// index and slice bounds failures are decode errors
+	err := recoverDecodeError(func() { _ = data[n] })
+	require.True(errors.Is(err, tezosprotocol.ErrOutOfBounds), err)
+	err = recoverDecodeError(func() { _ = data[:m] })
+	require.True(errors.Is(err, tezosprotocol.ErrOutOfBounds), err)
+	err = recoverDecodeError(func() { _ = data[n:] })
+	require.True(errors.Is(err, tezosprotocol.ErrOutOfBounds), err)
+	var runtimeErr runtime.Error
+	require.True(errors.As(err, &runtimeErr))
+
+	// other runtime errors are bugs rather than malformed input, so they re-panic
+	require.PanicsWithError("assignment to entry in nil map", func() {
+		_ = recoverDecodeError(func() {
+			var nilMap map[string]int
+			nilMap["key"] = 1
+		})
+	})
+	require.Panics(func() {
+		_ = recoverDecodeError(func() {
+			var nilPointer *tezosprotocol.Transaction
+			_ = nilPointer.Amount
+		})
+	})
+	require.PanicsWithValue("not a runtime error", func() {
+		_ = recoverDecodeError(func() { panic("not a runtime error") })
+	})
+}
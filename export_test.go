@@ -0,0 +1,4 @@
+package tezosprotocol
+
+// CatchOutOfRangeExceptions exposes catchOutOfRangeExceptions to tests
+var CatchOutOfRangeExceptions = catchOutOfRangeExceptions
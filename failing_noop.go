@@ -0,0 +1,98 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// FailingNoop models the tezos failing_noop operation type. It always fails to
+// apply, so an operation made of it can be signed as proof of ownership of a key
+// without ever being injectable.
+type FailingNoop struct {
+	Arbitrary []byte
+}
+
+func (f *FailingNoop) String() string {
+	return fmt.Sprintf("%#v", f)
+}
+
+// GetTag implements OperationContents
+func (f *FailingNoop) GetTag() ContentsTag {
+	return ContentsTagFailingNoop
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *FailingNoop) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(f.GetTag()))
+
+	// arbitrary
+	err := binary.Write(&buf, binary.BigEndian, uint32(len(f.Arbitrary)))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write arbitrary length: %w", err)
+	}
+	buf.Write(f.Arbitrary)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (f *FailingNoop) UnmarshalBinary(data []byte) error {
+	_, err := f.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the failing noop at the start of data and returns the number
+// of bytes it occupies
+func (f *FailingNoop) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagFailingNoop {
+		return 0, xerrors.Errorf("invalid tag for failing noop. Expected %d, saw %d", ContentsTagFailingNoop, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// arbitrary
+	arbitrary, err := readLengthPrefixed(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal arbitrary: %w", err)
+	}
+	f.Arbitrary = append([]byte{}, arbitrary...)
+	dataPtr = dataPtr[4+len(arbitrary):]
+
+	return len(data) - len(dataPtr), nil
+}
+
+// SignMessageWithFailingNoop signs message as the failing_noop operation on top of
+// branch. Unlike SignMessage, which uses the non-standard TextWatermark, this is
+// the standard way to sign arbitrary payloads, and the signature can't be used to
+// inject anything.
+func SignMessageWithFailingNoop(message []byte, branch BranchID, privateKey PrivateKey) (Signature, error) {
+	operation := &Operation{Branch: branch, Contents: []OperationContents{&FailingNoop{Arbitrary: message}}}
+	signedOperation, err := SignOperation(operation, privateKey)
+	return signedOperation.Signature, err
+}
+
+// VerifyMessageWithFailingNoop verifies a signature made by
+// SignMessageWithFailingNoop
+func VerifyMessageWithFailingNoop(message []byte, branch BranchID, signature Signature, publicKey crypto.PublicKey) error {
+	operation := &Operation{Branch: branch, Contents: []OperationContents{&FailingNoop{Arbitrary: message}}}
+	return operation.AttachSignature(signature).Verify(publicKey)
+}
@@ -0,0 +1,46 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailingNoop(t *testing.T) {
+	require := require.New(t)
+	failingNoop := &tezosprotocol.FailingNoop{Arbitrary: []byte("hello")}
+	encodedBytes, err := failingNoop.MarshalBinary()
+	require.NoError(err)
+	require.Equal("11"+"00000005"+"68656c6c6f", hex.EncodeToString(encodedBytes))
+
+	decoded := &tezosprotocol.FailingNoop{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(failingNoop, decoded)
+
+	// truncated
+	require.Error(decoded.UnmarshalBinary(encodedBytes[:len(encodedBytes)-1]))
+}
+
+func TestSignMessageWithFailingNoop(t *testing.T) {
+	require := require.New(t)
+	branch := tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB")
+	privateKey := tezosprotocol.PrivateKey("edskRc9Pr1NKUW9x6kAZb9cFerBWMo9X9dW4fXwzzL2rvKyKPfdJaJVUcYCfR37sbBujAXJXVJZoCXsUHzfhNcWuqy9aGunQPk")
+	publicKey, err := privateKey.PublicKey()
+	require.NoError(err)
+	cryptoPublicKey, err := publicKey.CryptoPublicKey()
+	require.NoError(err)
+
+	signature, err := tezosprotocol.SignMessageWithFailingNoop([]byte("hello"), branch, privateKey)
+	require.NoError(err)
+	require.NoError(tezosprotocol.VerifyMessageWithFailingNoop([]byte("hello"), branch, signature, cryptoPublicKey))
+	require.Error(tezosprotocol.VerifyMessageWithFailingNoop([]byte("goodbye"), branch, signature, cryptoPublicKey))
+
+	// the signature is over the operation bytes with the operation watermark
+	message, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f" + "110000000568656c6c6f")
+	require.NoError(err)
+	ok, err := signature.Verify(tezosprotocol.OperationWatermark, message, cryptoPublicKey)
+	require.NoError(err)
+	require.True(ok)
+}
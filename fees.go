@@ -1,6 +1,10 @@
 package tezosprotocol
 
-import "math/big"
+import (
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
 
 // ComputeMinimumFee returns the minimum fee required according to the constraint:
 //   fees >= (minimal_fees + minimal_nanotez_per_byte * size + minimal_nanotez_per_gas_unit * gas)
@@ -19,6 +23,84 @@ func ComputeMinimumFee(gasLimit, operationSizeBytes *big.Int) *big.Int {
 	return totalFee
 }
 
+// ComputeMinimumFeeForOperation returns the minimum fee for the unsigned operation op
+// with the given total gas limit. The size charged is that of the signed operation,
+// i.e. the marshaled operation plus OperationSignatureLen bytes of signature.
+func ComputeMinimumFeeForOperation(op *Operation, gasLimit *big.Int) (*big.Int, error) {
+	opBytes, err := op.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal operation: %w", err)
+	}
+	size := big.NewInt(int64(len(opBytes) + OperationSignatureLen))
+	return ComputeMinimumFee(gasLimit, size), nil
+}
+
+// EstimateFees apportions the minimum fee of op across its contents, given the gas
+// limit of each content. Each content is charged for its own marshaled size and gas,
+// and the first content is also charged the flat DefaultMinimalFees and the size of
+// the branch and signature. Sizes are measured with the contents' current fees, so
+// setting the returned fees may change the sizes slightly.
+func EstimateFees(op *Operation, gasPerContent []*big.Int) ([]*big.Int, error) {
+	if len(gasPerContent) != len(op.Contents) {
+		return nil, xerrors.Errorf("got %d gas limits for %d contents", len(gasPerContent), len(op.Contents))
+	}
+	fees := make([]*big.Int, len(op.Contents))
+	for i, content := range op.Contents {
+		contentBytes, err := content.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal operation contents: %w", err)
+		}
+		size := int64(len(contentBytes))
+		if i == 0 {
+			size += BlockHashLen + OperationSignatureLen
+		}
+		fee := ComputeMinimumFee(gasPerContent[i], big.NewInt(size))
+		if i > 0 {
+			// the flat fee is paid once per operation
+			fee.Sub(fee, big.NewInt(DefaultMinimalFees))
+		}
+		fees[i] = fee
+	}
+	return fees, nil
+}
+
+// ComputeStorageBurn returns the mutez that op may burn for storage. Each manager
+// content may burn up to its storage limit at StorageCostPerByte. Contents that
+// allocate an account burn at least NewAccountCreationBurn, even if their storage
+// limit is lower: originations always allocate a KT1 account, and transactions
+// allocate their destination if it is an implicit account that doesn't exist yet.
+// Whether it exists is only known to the node, so isNewAccount is asked for each
+// implicit destination. A nil isNewAccount assumes all destinations exist.
+func ComputeStorageBurn(op *Operation, isNewAccount func(ContractID) bool) (*big.Int, error) {
+	total := big.NewInt(0)
+	for i, content := range op.Contents {
+		fields, ok := getManagerFields(content)
+		if !ok {
+			continue
+		}
+		burn := big.NewInt(0)
+		if *fields.StorageLimit != nil {
+			burn.Mul(*fields.StorageLimit, big.NewInt(StorageCostPerByte))
+		}
+		allocates := false
+		switch c := content.(type) {
+		case *Origination:
+			allocates = true
+		case *Transaction:
+			accountType, err := c.Destination.AccountType()
+			if err != nil {
+				return nil, xerrors.Errorf("content %d: %w", i, err)
+			}
+			allocates = accountType == AccountTypeImplicit && isNewAccount != nil && isNewAccount(c.Destination)
+		}
+		if allocates && burn.Cmp(big.NewInt(NewAccountCreationBurn)) < 0 {
+			burn.SetInt64(NewAccountCreationBurn)
+		}
+		total.Add(total, burn)
+	}
+	return total, nil
+}
+
 // Common values for fees
 const (
 	// StorageCostPerByte is the amount of mutez burned per byte of storage used.
@@ -75,4 +157,13 @@ const (
 	// DelegationStorageBurn is the amount burned by an account as a consequence
 	// of signing a delegation. Note that it is zero.
 	DelegationStorageBurn = DelegationStorageLimitBytes * StorageCostPerByte
+
+	// HardGasLimitPerOperation is the maximum gas a single operation may consume.
+	// Reference: https://gitlab.com/tezos/tezos/blob/master/src/proto_alpha/lib_parameters/default_parameters.ml
+	HardGasLimitPerOperation = int64(1040000)
+
+	// HardStorageLimitPerOperation is the maximum storage in bytes a single operation
+	// may use.
+	// Reference: https://gitlab.com/tezos/tezos/blob/master/src/proto_alpha/lib_parameters/default_parameters.ml
+	HardStorageLimitPerOperation = int64(60000)
 )
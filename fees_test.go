@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
 )
 
 func TestComputeMinimumFee(t *testing.T) {
@@ -37,3 +38,85 @@ func TestComputeMinimumFee(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeMinimumFeeForOperation(t *testing.T) {
+	require := require.New(t)
+	operation := &tezosprotocol.Operation{
+		Branch: tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{
+			&tezosprotocol.Transaction{
+				Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+				Fee:          big.NewInt(50000),
+				Counter:      big.NewInt(2),
+				GasLimit:     big.NewInt(200),
+				StorageLimit: big.NewInt(0),
+				Amount:       big.NewInt(100000000),
+				Destination:  tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"),
+			},
+		},
+	}
+	opBytes, err := operation.MarshalBinary()
+	require.NoError(err)
+	fee, err := tezosprotocol.ComputeMinimumFeeForOperation(operation, big.NewInt(10207))
+	require.NoError(err)
+	// 100 flat + 1 per byte of the signed operation + 0.1 per gas unit
+	require.Equal(big.NewInt(int64(100+len(opBytes)+tezosprotocol.OperationSignatureLen+1020)), fee)
+
+	_, err = tezosprotocol.ComputeMinimumFeeForOperation(&tezosprotocol.Operation{}, big.NewInt(10207))
+	require.Error(err)
+}
+
+func TestEstimateFees(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	revelation := tezosprotocol.NewReveal(source, tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"))
+	revelation.Counter = big.NewInt(1)
+	transaction := tezosprotocol.NewTransfer(source, tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"), 1)
+	transaction.Counter = big.NewInt(2)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{revelation, transaction},
+	}
+	revelationBytes, err := revelation.MarshalBinary()
+	require.NoError(err)
+	transactionBytes, err := transaction.MarshalBinary()
+	require.NoError(err)
+
+	fees, err := tezosprotocol.EstimateFees(operation, []*big.Int{big.NewInt(10000), big.NewInt(10207)})
+	require.NoError(err)
+	require.Len(fees, 2)
+	// the first content pays the flat fee and the branch and signature bytes
+	require.Equal(big.NewInt(int64(100+len(revelationBytes)+tezosprotocol.BlockHashLen+tezosprotocol.OperationSignatureLen+1000)), fees[0])
+	require.Equal(big.NewInt(int64(len(transactionBytes)+1020)), fees[1])
+
+	_, err = tezosprotocol.EstimateFees(operation, []*big.Int{big.NewInt(10000)})
+	require.Error(err)
+}
+
+func TestComputeStorageBurn(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	newAccount := tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+	existingAccount := tezosprotocol.ContractID("tz1c8PEDNfj6UxoQM2XCyfTHM5KbGGgoqDrH")
+	isNewAccount := func(contractID tezosprotocol.ContractID) bool { return contractID == newAccount }
+
+	toNewAccount := tezosprotocol.NewTransfer(source, newAccount, 1)
+	toNewAccount.StorageLimit = big.NewInt(0)
+	toExistingAccount := tezosprotocol.NewTransfer(source, existingAccount, 1)
+	toExistingAccount.StorageLimit = big.NewInt(0)
+	contractCall := tezosprotocol.NewTransfer(source, tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq"), 1)
+	contractCall.StorageLimit = big.NewInt(1000)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{toNewAccount, toExistingAccount, contractCall},
+	}
+
+	burn, err := tezosprotocol.ComputeStorageBurn(operation, isNewAccount)
+	require.NoError(err)
+	require.Equal(big.NewInt(tezosprotocol.NewAccountCreationBurn+1000*tezosprotocol.StorageCostPerByte), burn)
+
+	// without a predicate, destinations are assumed to exist
+	burn, err = tezosprotocol.ComputeStorageBurn(operation, nil)
+	require.NoError(err)
+	require.Equal(big.NewInt(1000*tezosprotocol.StorageCostPerByte), burn)
+}
@@ -0,0 +1,37 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// Forge encodes an unsigned operation to its binary form. It is equivalent to
+// Operation.MarshalBinary, named after the node RPC and other tezos libraries.
+func Forge(operation *Operation) ([]byte, error) {
+	return operation.MarshalBinary()
+}
+
+// Unforge decodes an unsigned operation from its binary form. It errors unless
+// data is exactly one operation, with no trailing bytes.
+func Unforge(data []byte) (*Operation, error) {
+	operation := &Operation{}
+	if err := operation.UnmarshalBinary(data); err != nil {
+		return nil, xerrors.Errorf("failed to unforge operation: %w", err)
+	}
+	forged, err := operation.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unforge operation: %w", err)
+	}
+	if len(forged) != len(data) {
+		return nil, xerrors.Errorf("failed to unforge operation: consumed %d of %d bytes", len(forged), len(data))
+	}
+	return operation, nil
+}
+
+// UnforgeSigned decodes a signed operation from its binary form, i.e. an operation
+// followed by its signature. The signature type is inferred as by
+// SignedOperation.UnmarshalBinary.
+func UnforgeSigned(data []byte) (*SignedOperation, error) {
+	signedOperation := &SignedOperation{}
+	if err := signedOperation.UnmarshalBinary(data); err != nil {
+		return nil, xerrors.Errorf("failed to unforge signed operation: %w", err)
+	}
+	return signedOperation, nil
+}
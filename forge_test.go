@@ -0,0 +1,43 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForge(t *testing.T) {
+	require := require.New(t)
+	// branch || endorsement(level=450000)
+	forgedHex := "e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd0"
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{&tezosprotocol.Endorsement{Level: 450000}},
+	}
+	forged, err := tezosprotocol.Forge(operation)
+	require.NoError(err)
+	require.Equal(forgedHex, hex.EncodeToString(forged))
+
+	unforged, err := tezosprotocol.Unforge(forged)
+	require.NoError(err)
+	require.Equal(operation, unforged)
+
+	// trailing garbage
+	_, err = tezosprotocol.Unforge(append(forged, 0xfe))
+	require.Error(err)
+	require.Contains(err.Error(), "at byte 37 of 38")
+}
+
+func TestUnforgeSigned(t *testing.T) {
+	require := require.New(t)
+	signedOperationBytes := fromHex("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	signedOperation, err := tezosprotocol.UnforgeSigned(signedOperationBytes)
+	require.NoError(err)
+	require.Equal([]tezosprotocol.OperationContents{&tezosprotocol.Endorsement{Level: 450000}}, signedOperation.Operation.Contents)
+	require.True(signedOperation.IsSigned())
+
+	_, err = tezosprotocol.UnforgeSigned(signedOperationBytes[:40])
+	require.Error(err)
+}
@@ -10,5 +10,6 @@ require (
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/stretchr/testify v1.8.0
 	golang.org/x/crypto v0.8.0
+	golang.org/x/text v0.9.0
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1
 )
@@ -0,0 +1,98 @@
+package tezosprotocol
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// SLIP-0010 ed25519 derivation parameters.
+// Reference: https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+const (
+	slip10Ed25519Curve = "ed25519 seed"
+	hardenedKeyOffset  = uint32(1) << 31
+)
+
+// DeriveChild derives the ed25519 seed at path from this seed using SLIP-0010,
+// with this seed as the SLIP-0010 master seed. Paths look like m/44'/1729'/0'/0'.
+// ed25519 only supports hardened derivation, so every index must be marked
+// hardened with ' or h.
+//
+// Wallets such as Kukai and Galleon derive from the full 64-byte BIP39 seed rather
+// than from its first 32 bytes; use NewPrivateKeySeedFromMnemonicPath to get
+// matching keys.
+func (p PrivateKeySeed) DeriveChild(path string) (PrivateKeySeed, error) {
+	b58prefix, seedBytes, err := Base58CheckDecode(string(p))
+	if err != nil {
+		return "", xerrors.Errorf("failed to base58check decode seed: %w", err)
+	}
+	if b58prefix != PrefixEd25519Seed {
+		return "", xerrors.Errorf("unsupported private key seed prefix %s", b58prefix)
+	}
+	return deriveEd25519Seed(seedBytes, path)
+}
+
+// NewPrivateKeySeedFromMnemonicPath derives the ed25519 seed at path from a BIP39
+// mnemonic and passphrase using SLIP-0010, as HD wallets do. The mnemonic is
+// validated as by NewPrivateKeySeedFromMnemonic. The standard tezos path for
+// account n is m/44'/1729'/n'/0'.
+func NewPrivateKeySeedFromMnemonicPath(mnemonic, passphrase, path string) (PrivateKeySeed, error) {
+	bip39Seed, err := newBIP39Seed(mnemonic, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return deriveEd25519Seed(bip39Seed, path)
+}
+
+func deriveEd25519Seed(masterSeed []byte, path string) (PrivateKeySeed, error) {
+	indexes, err := parseHardenedDerivationPath(path)
+	if err != nil {
+		return "", err
+	}
+	key, chainCode := slip10Ed25519Step([]byte(slip10Ed25519Curve), masterSeed)
+	for _, index := range indexes {
+		data := make([]byte, 1+len(key)+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[1+len(key):], index)
+		key, chainCode = slip10Ed25519Step(chainCode, data)
+	}
+	seed, err := Base58CheckEncode(PrefixEd25519Seed, key)
+	if err != nil {
+		return "", xerrors.Errorf("failed to encode derived seed: %w", err)
+	}
+	return PrivateKeySeed(seed), nil
+}
+
+// slip10Ed25519Step returns the key and chain code given by HMAC-SHA512(key, data)
+func slip10Ed25519Step(key []byte, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data) //nolint:errcheck
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// parseHardenedDerivationPath parses a derivation path such as m/44'/1729'/0'/0'
+// into its indexes, with the hardened offset applied
+func parseHardenedDerivationPath(path string) ([]uint32, error) {
+	components := strings.Split(path, "/")
+	if components[0] != "m" {
+		return nil, xerrors.Errorf("invalid derivation path %q: must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(components)-1)
+	for _, component := range components[1:] {
+		trimmed := strings.TrimRight(component, "'h")
+		if len(component)-len(trimmed) != 1 {
+			return nil, xerrors.Errorf("invalid derivation path %q: ed25519 only supports hardened indexes, saw %q", path, component)
+		}
+		index, err := strconv.ParseUint(trimmed, 10, 31)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid derivation path %q: bad index %q: %w", path, component, err)
+		}
+		indexes = append(indexes, uint32(index)+hardenedKeyOffset)
+	}
+	return indexes, nil
+}
@@ -0,0 +1,40 @@
+package tezosprotocol_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveChild(t *testing.T) {
+	require := require.New(t)
+	seed := tezosprotocol.PrivateKeySeed("edsk4AobYA4B3RDD6fdr9GChkU9dBsMAm46nWZawx5hHV4PUkKhQJK")
+	child, err := seed.DeriveChild("m/44'/1729'/0'/0'")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrivateKeySeed("edsk3ztAM8aJ4KgSGxcgXgEaKUqLTtexVNnS7gj5YJPFALze46wQBM"), child)
+	hChild, err := seed.DeriveChild("m/44h/1729h/0h/0h")
+	require.NoError(err)
+	require.Equal(child, hChild)
+
+	for _, path := range []string{"m/44'/1729'/0'/0", "44'/1729'", "m/44''", "m/-1'", "m/2147483648'"} {
+		_, err = seed.DeriveChild(path)
+		require.Error(err, path)
+	}
+}
+
+func TestNewPrivateKeySeedFromMnemonicPath(t *testing.T) {
+	require := require.New(t)
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+	seed, err := tezosprotocol.NewPrivateKeySeedFromMnemonicPath(mnemonic, "", "m/44'/1729'/0'/0'")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrivateKeySeed("edsk4BBVKnpwdnJrx9PB4hLkXZHtceSdSZVTfKBXArhmZ3Jg87Lcxi"), seed)
+	privateKey, err := seed.PrivateKey()
+	require.NoError(err)
+	publicKey, err := privateKey.PublicKey()
+	require.NoError(err)
+	address, err := tezosprotocol.NewContractIDFromPublicKey(publicKey)
+	require.NoError(err)
+	require.Equal(tezosprotocol.ContractID("tz1VQA4RP4fLjEEMW2FR4pE9kAg5abb5h5GL"), address)
+}
@@ -0,0 +1,192 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// IncreasePaidStorage models the tezos increase_paid_storage operation type, with
+// which the source prepays Amount bytes of storage for the originated contract
+// Destination.
+type IncreasePaidStorage struct {
+	Source       ContractID
+	Fee          *big.Int
+	Counter      *big.Int
+	GasLimit     *big.Int
+	StorageLimit *big.Int
+	Amount       *big.Int
+	Destination  ContractID
+}
+
+func (r *IncreasePaidStorage) String() string {
+	return fmt.Sprintf("%#v", r)
+}
+
+// GetTag implements OperationContents
+func (r *IncreasePaidStorage) GetTag() ContentsTag {
+	return ContentsTagIncreasePaidStorage
+}
+
+// GetSource returns the operation's source
+func (r *IncreasePaidStorage) GetSource() ContractID {
+	return r.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (r *IncreasePaidStorage) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(r.GetTag()))
+
+	// source
+	sourceBytes, err := r.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// fee
+	fee, err := zarith.Encode(r.Fee)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Fee: %w", err)
+	}
+	buf.Write(fee)
+
+	// counter
+	counter, err := zarith.Encode(r.Counter)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Counter: %w", err)
+	}
+	buf.Write(counter)
+
+	// gas limit
+	gasLimit, err := zarith.Encode(r.GasLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write GasLimit: %w", err)
+	}
+	buf.Write(gasLimit)
+
+	// storage limit
+	storageLimit, err := zarith.Encode(r.StorageLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write StorageLimit: %w", err)
+	}
+	buf.Write(storageLimit)
+
+	// amount
+	buf.Write(zarith.EncodeSigned(r.Amount))
+
+	// destination
+	if err := validateOriginatedDestination(r.Destination); err != nil {
+		return nil, err
+	}
+	destinationBytes, err := r.Destination.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write destination: %w", err)
+	}
+	buf.Write(destinationBytes)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *IncreasePaidStorage) UnmarshalBinary(data []byte) error {
+	_, err := r.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the increase paid storage at the start of data and returns the number
+// of bytes it occupies
+func (r *IncreasePaidStorage) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagIncreasePaidStorage {
+		return 0, xerrors.Errorf("invalid tag for increase paid storage. Expected %d, saw %d", ContentsTagIncreasePaidStorage, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = r.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// fee
+	var bytesRead int
+	r.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// counter
+	r.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// gas limit
+	r.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// storage limit
+	r.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// amount
+	r.Amount, bytesRead, err = zarith.ReadNextSigned(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal amount: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// destination
+	err = r.Destination.UnmarshalBinary(dataPtr[:ContractIDLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal destination: %w", err)
+	}
+	dataPtr = dataPtr[ContractIDLen:]
+	err = validateOriginatedDestination(r.Destination)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data) - len(dataPtr), nil
+}
+
+// validateOriginatedDestination checks that destination is a KT1 address, the only
+// kind of account with paid storage
+func validateOriginatedDestination(destination ContractID) error {
+	accountType, err := destination.AccountType()
+	if err != nil {
+		return xerrors.Errorf("invalid destination: %w", err)
+	}
+	if accountType != AccountTypeOriginated {
+		return xerrors.Errorf("destination %s must be an originated contract", destination)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncreasePaidStorage(t *testing.T) {
+	require := require.New(t)
+	increasePaidStorage := &tezosprotocol.IncreasePaidStorage{
+		Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Fee:          big.NewInt(1000),
+		Counter:      big.NewInt(2),
+		GasLimit:     big.NewInt(1500),
+		StorageLimit: big.NewInt(0),
+		Amount:       big.NewInt(100),
+		Destination:  tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq"),
+	}
+	destinationBytes, err := increasePaidStorage.Destination.MarshalBinary()
+	require.NoError(err)
+	encodedBytes, err := increasePaidStorage.MarshalBinary()
+	require.NoError(err)
+	// amount is a signed zarith
+	require.Equal("710002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b00"+"a401"+hex.EncodeToString(destinationBytes), hex.EncodeToString(encodedBytes))
+
+	decoded := &tezosprotocol.IncreasePaidStorage{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(increasePaidStorage, decoded)
+
+	// only originated contracts have paid storage
+	increasePaidStorage.Destination = tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+	_, err = increasePaidStorage.MarshalBinary()
+	require.Error(err)
+}
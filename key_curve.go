@@ -0,0 +1,73 @@
+package tezosprotocol
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// KeyCurve identifies the elliptic curve of a key
+type KeyCurve int
+
+// KeyCurve values
+const (
+	KeyCurveEd25519 KeyCurve = iota + 1
+	KeyCurveSecp256k1
+	KeyCurveP256
+)
+
+func (k KeyCurve) String() string {
+	switch k {
+	case KeyCurveEd25519:
+		return "ed25519"
+	case KeyCurveSecp256k1:
+		return "secp256k1"
+	case KeyCurveP256:
+		return "p256"
+	default:
+		return "<invalid curve>"
+	}
+}
+
+// keyCurvePrefix is the leading text and total length of a base58check encoded key
+type keyCurvePrefix struct {
+	text   string
+	length int
+	curve  KeyCurve
+}
+
+var (
+	publicKeyCurvePrefixes = []keyCurvePrefix{
+		{text: "edpk", length: 54, curve: KeyCurveEd25519},
+		{text: "sppk", length: 55, curve: KeyCurveSecp256k1},
+		{text: "p2pk", length: 55, curve: KeyCurveP256},
+	}
+	privateKeyCurvePrefixes = []keyCurvePrefix{
+		{text: "edsk", length: 98, curve: KeyCurveEd25519},
+		{text: "spsk", length: 54, curve: KeyCurveSecp256k1},
+		{text: "p2sk", length: 54, curve: KeyCurveP256},
+	}
+)
+
+func curveFromPrefix(key string, prefixes []keyCurvePrefix) (KeyCurve, error) {
+	for _, prefix := range prefixes {
+		if len(key) == prefix.length && strings.HasPrefix(key, prefix.text) {
+			return prefix.curve, nil
+		}
+	}
+	return 0, xerrors.New("unrecognized key prefix")
+}
+
+// Curve returns the curve of the public key, judging by its base58check prefix and
+// length alone. It does not decode the key, so it does not check the checksum or
+// that the key is a point on the curve; CryptoPublicKey does.
+func (p PublicKey) Curve() (KeyCurve, error) {
+	return curveFromPrefix(string(p), publicKeyCurvePrefixes)
+}
+
+// Curve returns the curve of the private key, judging by its base58check prefix and
+// length alone. It does not decode the key, so it does not check the checksum;
+// CryptoPrivateKey does.
+func (p PrivateKey) Curve() (KeyCurve, error) {
+	return curveFromPrefix(string(p), privateKeyCurvePrefixes)
+}
@@ -0,0 +1,33 @@
+package tezosprotocol_test
+
+import (
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCurve(t *testing.T) {
+	require := require.New(t)
+	expectedCurves := map[string]tezosprotocol.KeyCurve{
+		"Ed25519":   tezosprotocol.KeyCurveEd25519,
+		"secp256k1": tezosprotocol.KeyCurveSecp256k1,
+		"P256":      tezosprotocol.KeyCurveP256,
+	}
+	for _, testCase := range keysTestCases {
+		if !testCase.SupportedKeyType {
+			continue
+		}
+		privateKeyCurve, err := testCase.ExpectedPrivateKey.Curve()
+		require.NoError(err, testCase.KeyType)
+		require.Equal(expectedCurves[testCase.KeyType], privateKeyCurve, testCase.KeyType)
+		publicKeyCurve, err := testCase.ExpectedPublicKey.Curve()
+		require.NoError(err, testCase.KeyType)
+		require.Equal(expectedCurves[testCase.KeyType], publicKeyCurve, testCase.KeyType)
+	}
+
+	_, err := tezosprotocol.PublicKey("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx").Curve()
+	require.Error(err)
+	_, err = tezosprotocol.PrivateKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav").Curve()
+	require.Error(err)
+}
@@ -5,10 +5,12 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/subtle"
 	"math/big"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/ed25519"
 	"golang.org/x/xerrors"
 )
@@ -60,30 +62,21 @@ func NewPublicKeyFromCryptoPublicKey(cryptoPubKey crypto.PublicKey) (PublicKey,
 	case ed25519.PublicKey:
 		ret, err := Base58CheckEncode(PrefixEd25519PublicKey, key)
 		return PublicKey(ret), err
+	case *ecdsa.PublicKey:
+		return NewPublicKeyFromCryptoPublicKey(*key)
 	case ecdsa.PublicKey:
+		var b58Prefix Base58CheckPrefix
 		switch key.Curve {
 		case btcec.S256():
-			// btcec.ParsePubKey(key)
-			x := &secp256k1.FieldVal{}
-			x.SetByteSlice(key.X.Bytes())
-			y := &secp256k1.FieldVal{}
-			y.SetByteSlice(key.X.Bytes())
-			btcSuitePublicKey := btcec.NewPublicKey(x, y)
-			compressedPubKeyBytes := btcSuitePublicKey.SerializeCompressed()
-			ret, err := Base58CheckEncode(PrefixSecp256k1PublicKey, compressedPubKeyBytes)
-			return PublicKey(ret), err
+			b58Prefix = PrefixSecp256k1PublicKey
 		case elliptic.P256():
-			x := &secp256k1.FieldVal{}
-			x.SetByteSlice(key.X.Bytes())
-			y := &secp256k1.FieldVal{}
-			y.SetByteSlice(key.X.Bytes())
-			btcSuitePublicKey := btcec.NewPublicKey(x, y)
-			compressedPubKeyBytes := btcSuitePublicKey.SerializeCompressed()
-			ret, err := Base58CheckEncode(PrefixP256PublicKey, compressedPubKeyBytes)
-			return PublicKey(ret), err
+			b58Prefix = PrefixP256PublicKey
 		default:
 			return "", xerrors.Errorf("unsupported curve %s", key.Curve)
 		}
+		compressedPubKeyBytes := elliptic.MarshalCompressed(key.Curve, key.X, key.Y)
+		ret, err := Base58CheckEncode(b58Prefix, compressedPubKeyBytes)
+		return PublicKey(ret), err
 	default:
 		return "", xerrors.Errorf("unsupported public key type %T", cryptoPubKey)
 	}
@@ -105,9 +98,62 @@ func (p PublicKey) CryptoPublicKey() (crypto.PublicKey, error) {
 		}
 		return btcecPublicKey.ToECDSA(), nil
 	case PrefixP256PublicKey:
-		return nil, xerrors.New("unable to deserialize compressed P256 keys")
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), b58decoded)
+		if x == nil {
+			return nil, xerrors.Errorf("invalid compressed P256 public key: %s", p)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
 	default:
-		return nil, xerrors.Errorf("unexpected base58check prefix: %s", p)
+		return nil, xerrors.Errorf("%w: %s", ErrUnexpectedPrefix, p)
+	}
+}
+
+// Hash returns the public key hash, i.e. the address of the implicit account
+// (tz1, tz2 or tz3) controlled by this key. It is the blake2b hash of the key
+// bytes, which are compressed for secp256k1 and P256 keys.
+func (p PublicKey) Hash() (ContractID, error) {
+	b58prefix, b58decoded, err := Base58CheckDecode(string(p))
+	if err != nil {
+		return "", err
+	}
+	var hashPrefix Base58CheckPrefix
+	switch b58prefix {
+	case PrefixEd25519PublicKey:
+		hashPrefix = PrefixEd25519PublicKeyHash
+	case PrefixSecp256k1PublicKey:
+		hashPrefix = PrefixSecp256k1PublicKeyHash
+	case PrefixP256PublicKey:
+		hashPrefix = PrefixP256PublicKeyHash
+	default:
+		return "", xerrors.Errorf("%w: %s", ErrUnexpectedPrefix, p)
+	}
+	pubKeyHash, err := blake2b.New(PubKeyHashLen, nil)
+	if err != nil {
+		panic(xerrors.Errorf("failed to create blake2b hash: %w", err))
+	}
+	pubKeyHash.Write(b58decoded) //nolint:errcheck
+	address, err := Base58CheckEncode(hashPrefix, pubKeyHash.Sum(nil))
+	if err != nil {
+		return "", xerrors.Errorf("failed to base58check encode hash: %w", err)
+	}
+	return ContractID(address), nil
+}
+
+// signatureScheme returns the scheme of the signatures made by this key
+func (p PublicKey) signatureScheme() (SignatureScheme, error) {
+	b58prefix, _, err := Base58CheckDecode(string(p))
+	if err != nil {
+		return 0, err
+	}
+	switch b58prefix {
+	case PrefixEd25519PublicKey:
+		return SignatureSchemeEd25519, nil
+	case PrefixSecp256k1PublicKey:
+		return SignatureSchemeSecp256k1, nil
+	case PrefixP256PublicKey:
+		return SignatureSchemeP256, nil
+	default:
+		return 0, xerrors.Errorf("%w: %s", ErrUnexpectedPrefix, p)
 	}
 }
 
@@ -133,7 +179,7 @@ func (p PublicKey) MarshalBinary() ([]byte, error) {
 		expectedPkLength = PubKeyLenP256
 		buf.WriteByte(byte(PubKeyTagP256))
 	default:
-		return nil, xerrors.Errorf("unexpected base58check prefix: %s", p)
+		return nil, xerrors.Errorf("%w: %s", ErrUnexpectedPrefix, p)
 	}
 
 	// write the public key
@@ -146,8 +192,15 @@ func (p PublicKey) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler
 func (p *PublicKey) UnmarshalBinary(data []byte) error {
+	_, err := p.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary reads a $public_key from the start of data and returns the
+// number of bytes consumed. Trailing bytes are ignored.
+func (p *PublicKey) unmarshalBinary(data []byte) (int, error) {
 	if len(data) < 1 {
-		return xerrors.Errorf("too few bytes to unmarshal public_key")
+		return 0, xerrors.Errorf("too few bytes to unmarshal public_key")
 	}
 	pubKeyTag := PubKeyTag(data[0])
 	pubKey := data[1:]
@@ -165,18 +218,18 @@ func (p *PublicKey) UnmarshalBinary(data []byte) error {
 		expectedLength = PubKeyLenP256
 		base58checkPrefix = PrefixP256PublicKey
 	default:
-		return xerrors.Errorf("invalid public_key tag %d", pubKeyTag)
+		return 0, xerrors.Errorf("invalid public_key tag %d", pubKeyTag)
 	}
 
 	if len(pubKey) < expectedLength {
-		return xerrors.Errorf("too few bytes to unmarshal public_key")
+		return 0, xerrors.Errorf("too few bytes to unmarshal public_key")
 	}
 	encoded, err := Base58CheckEncode(base58checkPrefix, pubKey[:expectedLength])
 	if err != nil {
-		return err
+		return 0, err
 	}
 	*p = PublicKey(encoded)
-	return nil
+	return 1 + expectedLength, nil
 }
 
 // PrivateKey encodes a tezos private key in base58check encoding
@@ -201,10 +254,11 @@ func NewPrivateKeyFromCryptoPrivateKey(cryptoPrivateKey crypto.PrivateKey) (Priv
 			ret, err := Base58CheckEncode(PrefixSecp256k1SecretKey, privKeyBytes)
 			return PrivateKey(ret), err
 		case elliptic.P256():
-			d := &secp256k1.ModNScalar{}
-			d.SetByteSlice(key.D.Bytes())
-			btcSuitePrivateKey := btcec.PrivKeyFromScalar(d)
-			privKeyBytes := btcSuitePrivateKey.Serialize()
+			// the scalar is already reduced modulo the P256 order, so it is written as is
+			if key.D.Sign() <= 0 || key.D.Cmp(elliptic.P256().Params().N) >= 0 {
+				return "", xerrors.New("P256 private scalar out of range")
+			}
+			privKeyBytes := key.D.FillBytes(make([]byte, 32))
 			ret, err := Base58CheckEncode(PrefixP256SecretKey, privKeyBytes)
 			return PrivateKey(ret), err
 		default:
@@ -239,6 +293,19 @@ func (p PrivateKey) CryptoPrivateKey() (crypto.PrivateKey, error) {
 	}
 }
 
+// PublicKey returns the public key corresponding to this private key
+func (p PrivateKey) PublicKey() (PublicKey, error) {
+	cryptoPrivateKey, err := p.CryptoPrivateKey()
+	if err != nil {
+		return "", err
+	}
+	signer, ok := cryptoPrivateKey.(crypto.Signer)
+	if !ok {
+		return "", xerrors.Errorf("unsupported private key type %T", cryptoPrivateKey)
+	}
+	return NewPublicKeyFromCryptoPublicKey(signer.Public())
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (p PrivateKey) MarshalBinary() ([]byte, error) {
 	b58prefix, b58decoded, err := Base58CheckDecode(string(p))
@@ -253,6 +320,22 @@ func (p PrivateKey) MarshalBinary() ([]byte, error) {
 	}
 }
 
+// Equal reports whether two private keys are the same key. Malformed keys are not
+// equal to anything. Only the comparison of the decoded keys is constant time: the
+// base58check decoding that precedes it is not, so Equal is not free of timing side
+// channels.
+func (p PrivateKey) Equal(other PrivateKey) bool {
+	prefix, decoded, err := Base58CheckDecode(string(p))
+	if err != nil {
+		return false
+	}
+	otherPrefix, otherDecoded, err := Base58CheckDecode(string(other))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(decoded, otherDecoded) == 1 && prefix == otherPrefix
+}
+
 // PrivateKeySeed encodes a tezos private key seed in base58check encoding.
 type PrivateKeySeed string
 
@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/hex"
+	"math/big"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
@@ -60,7 +61,7 @@ var keysTestCases = []keyTest{
 		ExpectedPublicKey:       tezosprotocol.PublicKey("p2pk653txU6DqbwmfVrpRjs3kWsMfFZD2bZxuDoMbNbu3FQ4s557mHT"),
 		ExpectedPublicKeyBytes:  fromHex("02023ef92fb44bb6d204854a511f775947ff762d493357c1b91205ba173171f61a2c"),
 		SupportedKeyType:        true,
-		CanDeserializePublicKey: false,
+		CanDeserializePublicKey: true,
 	}, {
 		KeyType:          "P224",
 		SupportedKeyType: false,
@@ -76,6 +77,13 @@ var keysTestCases = []keyTest{
 	},
 }
 
+func ecdsaPrivateKeyFromScalar(curve elliptic.Curve, scalar []byte) *ecdsa.PrivateKey {
+	privateKey := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(scalar)}
+	privateKey.Curve = curve
+	privateKey.X, privateKey.Y = curve.ScalarBaseMult(scalar)
+	return privateKey
+}
+
 func TestKeys(t *testing.T) {
 	require := require.New(t)
 	for _, testCase := range keysTestCases {
@@ -87,13 +95,13 @@ func TestKeys(t *testing.T) {
 			cryptoPublicKey, cryptoPrivateKey, err = ed25519.GenerateKey(bytes.NewReader(randSeed))
 			require.NoError(err)
 		case "secp256k1":
-			ecdsaPrivKey, err := ecdsa.GenerateKey(btcec.S256(), bytes.NewReader(randSeed))
-			require.NoError(err)
+			// ecdsa.GenerateKey is not deterministic for a given reader, so build
+			// the key from its expected scalar
+			ecdsaPrivKey := ecdsaPrivateKeyFromScalar(btcec.S256(), testCase.ExpectedPrivateKeyBytes)
 			cryptoPrivateKey = ecdsaPrivKey
 			cryptoPublicKey = ecdsaPrivKey.PublicKey
 		case "P256":
-			ecdsaPrivKey, err := ecdsa.GenerateKey(elliptic.P256(), bytes.NewReader(randSeed))
-			require.NoError(err)
+			ecdsaPrivKey := ecdsaPrivateKeyFromScalar(elliptic.P256(), testCase.ExpectedPrivateKeyBytes)
 			cryptoPrivateKey = ecdsaPrivKey
 			cryptoPublicKey = ecdsaPrivKey.PublicKey
 		case "P224":
@@ -151,3 +159,90 @@ func TestKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestPublicKeyUnmarshalTrailingBytes(t *testing.T) {
+	require := require.New(t)
+	publicKey := tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	publicKeyBytes, err := publicKey.MarshalBinary()
+	require.NoError(err)
+
+	// bytes after the public key are ignored
+	var decoded tezosprotocol.PublicKey
+	require.NoError(decoded.UnmarshalBinary(append(publicKeyBytes, 0x00, 0x01)))
+	require.Equal(publicKey, decoded)
+}
+
+func TestECDSAPublicKeyRoundTrip(t *testing.T) {
+	require := require.New(t)
+	for _, curve := range []elliptic.Curve{btcec.S256(), elliptic.P256()} {
+		// enough scalars to cover points with both odd and even Y
+		for i := byte(1); i <= 8; i++ {
+			privateKey := ecdsaPrivateKeyFromScalar(curve, bytes.Repeat([]byte{i}, 32))
+			publicKey, err := tezosprotocol.NewPublicKeyFromCryptoPublicKey(privateKey.PublicKey)
+			require.NoError(err)
+			cryptoPublicKey, err := publicKey.CryptoPublicKey()
+			require.NoError(err)
+			ecdsaPublicKey, ok := cryptoPublicKey.(*ecdsa.PublicKey)
+			require.True(ok)
+			require.Equal(0, privateKey.X.Cmp(ecdsaPublicKey.X), "%s", publicKey)
+			require.Equal(0, privateKey.Y.Cmp(ecdsaPublicKey.Y), "%s", publicKey)
+		}
+	}
+}
+
+func TestP256PrivateKeyRoundTrip(t *testing.T) {
+	require := require.New(t)
+	n := elliptic.P256().Params().N
+	for _, d := range []*big.Int{
+		big.NewInt(1),
+		new(big.Int).Sub(n, big.NewInt(1)),
+		new(big.Int).Sub(n, big.NewInt(12345)),
+	} {
+		cryptoPrivateKey := ecdsaPrivateKeyFromScalar(elliptic.P256(), d.Bytes())
+		privateKey, err := tezosprotocol.NewPrivateKeyFromCryptoPrivateKey(cryptoPrivateKey)
+		require.NoError(err)
+		decoded, err := privateKey.CryptoPrivateKey()
+		require.NoError(err)
+		require.Equal(0, d.Cmp(decoded.(*ecdsa.PrivateKey).D), d.String())
+	}
+
+	// scalars outside of [1, n) are not private keys
+	_, err := tezosprotocol.NewPrivateKeyFromCryptoPrivateKey(&ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()},
+		D:         n,
+	})
+	require.Error(err)
+}
+
+func TestPublicKeyHash(t *testing.T) {
+	require := require.New(t)
+	tests := map[tezosprotocol.PublicKey]tezosprotocol.ContractID{
+		"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav":  "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		"edpkuhEcwoLysLvodRxQLzuM3AVZvCuT6koVkUahS53mNBdE8LbuGo":  "tz1c8PEDNfj6UxoQM2XCyfTHM5KbGGgoqDrH",
+		"sppk7czDjVPj1o3hVLeErZTi6brjZNYGc6jFWzFVvW3oRnki3XB58Yq": "tz2WKGyvZgv7oJdm3WRQ17o6E6aojQcKcLi1",
+		"p2pk653txU6DqbwmfVrpRjs3kWsMfFZD2bZxuDoMbNbu3FQ4s557mHT": "tz3RD3Sw9BDqeQs1sh3mTMbB8D3jSd8a5GcN",
+	}
+	for publicKey, expected := range tests {
+		observed, err := publicKey.Hash()
+		require.NoError(err)
+		require.Equal(expected, observed)
+	}
+	_, err := tezosprotocol.PublicKey("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx").Hash()
+	require.Error(err)
+}
+
+func TestPrivateKeyEqual(t *testing.T) {
+	require := require.New(t)
+	privateKey := tezosprotocol.PrivateKey("spsk1S1KpLsBEXYYw3nQEGHdNQDTjpBsJH9Y86XZVJNobHFkxezaPv")
+	require.True(privateKey.Equal(tezosprotocol.PrivateKey("spsk1S1KpLsBEXYYw3nQEGHdNQDTjpBsJH9Y86XZVJNobHFkxezaPv")))
+	require.False(privateKey.Equal(tezosprotocol.PrivateKey("p2sk2Mg6PgZcQ3hvj3SV6CXZvSGthGM9T91YENMMAwemHKx2AJRxU6")))
+	require.False(privateKey.Equal(tezosprotocol.PrivateKey("")))
+	require.False(tezosprotocol.PrivateKey("").Equal(tezosprotocol.PrivateKey("")))
+
+	// the same scalar on different curves is a different key
+	scalar, err := privateKey.MarshalBinary()
+	require.NoError(err)
+	p256Key, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixP256SecretKey, scalar)
+	require.NoError(err)
+	require.False(privateKey.Equal(tezosprotocol.PrivateKey(p256Key)))
+}
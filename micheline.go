@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"math/big"
+	"strings"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
 )
 
 // incomplete Micheline implementation based on https://gitlab.com/tezos/tezos/blob/master/src%2Flib_micheline%2Fmicheline.ml
@@ -11,27 +15,27 @@ import (
 
 const (
 	// int
-	michelineTagInt byte = iota //nolint
+	michelineTagInt byte = iota
 	// string
 	michelineTagString
 	// sequence
-	michelineTagSeq //nolint
+	michelineTagSeq
 	// Prim (no args, annot)
 	michelineTagPrim0
 	// Prim (no args + annot)
-	michelineTagPrim0A //nolint
+	michelineTagPrim0A
 	// Prim (1 arg, no annot)
-	michelineTagPrim1 //nolint
+	michelineTagPrim1
 	// Prim (1 arg + annot)
-	michelineTagPrim1A //nolint
+	michelineTagPrim1A
 	// Prim (2 args, no annot)
-	michelineTagPrim2 //nolint
+	michelineTagPrim2
 	// Prim (2 args + annot)
-	michelineTagPrim2A //nolint
+	michelineTagPrim2A
 	// "application_encoding"
-	michelineTagApplication //nolint
+	michelineTagApplication
 	// bytes
-	michelineTagBytes //nolint
+	michelineTagBytes
 )
 
 // MichelineNode represents one node in the tree of Micheline expressions
@@ -48,12 +52,22 @@ func (*MichelineInt) isMichelineNode() {}
 
 // MarshalBinary implements the MichelineNode interface
 func (m MichelineInt) MarshalBinary() ([]byte, error) {
-	panic("not implemented")
+	value := big.Int(m)
+	return append([]byte{michelineTagInt}, zarith.EncodeSigned(&value)...), nil
 }
 
 // UnmarshalBinary implements the MichelineNode interface
-func (m *MichelineInt) UnmarshalBinary([]byte) error {
-	panic("not implemented")
+func (m *MichelineInt) UnmarshalBinary(data []byte) error {
+	node, _, err := UnmarshalMicheline(data)
+	if err != nil {
+		return err
+	}
+	value, ok := node.(*MichelineInt)
+	if !ok {
+		return xerrors.Errorf("expected a Micheline int, saw %T", node)
+	}
+	*m = *value
+	return nil
 }
 
 // MichelineString represents a string in a Micheline expression
@@ -69,8 +83,13 @@ func (m MichelineString) MarshalBinary() ([]byte, error) {
 }
 
 // UnmarshalBinary implements the MichelineNode interface
-func (m *MichelineString) UnmarshalBinary([]byte) error {
-	panic("not implemented")
+func (m *MichelineString) UnmarshalBinary(data []byte) error {
+	payload, err := readMichelineLengthPrefixed(data, michelineTagString)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal Micheline string: %w", err)
+	}
+	*m = MichelineString(payload)
+	return nil
 }
 
 // MichelineBytes represents a byte array in a Micheline expression
@@ -80,12 +99,76 @@ func (*MichelineBytes) isMichelineNode() {}
 
 // MarshalBinary implements the MichelineNode interface
 func (m MichelineBytes) MarshalBinary() ([]byte, error) {
-	panic("not implemented")
+	lenBuf := new(bytes.Buffer)
+	err := binary.Write(lenBuf, binary.BigEndian, uint32(len(m)))
+	return append(append([]byte{michelineTagBytes}, lenBuf.Bytes()...), m...), err
 }
 
 // UnmarshalBinary implements the MichelineNode interface
-func (m *MichelineBytes) UnmarshalBinary([]byte) error {
-	panic("not implemented")
+func (m *MichelineBytes) UnmarshalBinary(data []byte) error {
+	payload, err := readMichelineLengthPrefixed(data, michelineTagBytes)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal Micheline bytes: %w", err)
+	}
+	*m = append(MichelineBytes{}, payload...)
+	return nil
+}
+
+// readMichelineLengthPrefixed checks that data starts with tag and returns the
+// payload following it, whose length is given by a 4-byte big-endian prefix
+func readMichelineLengthPrefixed(data []byte, tag byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, xerrors.New("no bytes left to unmarshal a Micheline node")
+	}
+	if data[0] != tag {
+		return nil, xerrors.Errorf("invalid Micheline tag. Expected %d, saw %d", tag, data[0])
+	}
+	return readLengthPrefixed(data[1:])
+}
+
+// readLengthPrefixed returns the payload at the start of data, whose length is
+// given by a 4-byte big-endian prefix
+func readLengthPrefixed(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, xerrors.Errorf("too few bytes for a length prefix: %d", len(data))
+	}
+	length := binary.BigEndian.Uint32(data[:4])
+	if uint64(length) > uint64(len(data)-4) {
+		return nil, xerrors.Errorf("node declares %d bytes but only %d remain", length, len(data)-4)
+	}
+	return data[4 : 4+length], nil
+}
+
+// marshalLengthPrefixedMicheline serializes node preceded by its length in bytes as
+// a 4-byte big-endian integer, as Micheline expressions are embedded in operations
+func marshalLengthPrefixedMicheline(node MichelineNode) ([]byte, error) {
+	if node == nil {
+		return nil, xerrors.New("missing Micheline expression")
+	}
+	nodeBytes, err := node.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	lenBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(lenBytes, uint32(len(nodeBytes)))
+	return append(lenBytes, nodeBytes...), nil
+}
+
+// unmarshalLengthPrefixedMicheline is the inverse of marshalLengthPrefixedMicheline.
+// It returns the node and the number of bytes read, including the length prefix.
+func unmarshalLengthPrefixedMicheline(data []byte) (MichelineNode, int, error) {
+	nodeBytes, err := readLengthPrefixed(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	node, bytesRead, err := UnmarshalMicheline(nodeBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+	if bytesRead != len(nodeBytes) {
+		return nil, 0, xerrors.Errorf("expression declares %d bytes but its Micheline node occupies %d", len(nodeBytes), bytesRead)
+	}
+	return node, 4 + len(nodeBytes), nil
 }
 
 // MichelinePrim likely represents a Michelson primitive in a Micheline expression
@@ -97,17 +180,65 @@ type MichelinePrim struct {
 
 func (*MichelinePrim) isMichelineNode() {}
 
-// MarshalBinary implements the MichelineNode interface
-func (m MichelinePrim) MarshalBinary() ([]byte, error) { //nolint:unparam
-	if len(m.Args) == 0 && len(m.Annots) == 0 {
-		return []byte{michelineTagPrim0, m.Prim}, nil
+// MarshalBinary implements the MichelineNode interface. Primitives with up to two
+// arguments use the compact encodings; the rest use the general application form.
+func (m MichelinePrim) MarshalBinary() ([]byte, error) {
+	hasAnnots := len(m.Annots) > 0
+	var tag byte
+	if len(m.Args) <= 2 {
+		tag = michelineTagPrim0 + byte(2*len(m.Args))
+		if hasAnnots {
+			tag++
+		}
+	} else {
+		tag = michelineTagApplication
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteByte(tag)
+	buf.WriteByte(m.Prim)
+
+	// arguments
+	argsBuf := new(bytes.Buffer)
+	for i, arg := range m.Args {
+		argBytes, err := arg.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal primitive argument %d: %w", i, err)
+		}
+		argsBuf.Write(argBytes)
+	}
+	if tag == michelineTagApplication {
+		err := binary.Write(buf, binary.BigEndian, uint32(argsBuf.Len()))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal primitive arguments length: %w", err)
+		}
+	}
+	buf.Write(argsBuf.Bytes())
+
+	// annotations
+	if hasAnnots || tag == michelineTagApplication {
+		annots := strings.Join(m.Annots, " ")
+		err := binary.Write(buf, binary.BigEndian, uint32(len(annots)))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal primitive annotations length: %w", err)
+		}
+		buf.WriteString(annots)
 	}
-	panic("not implemented")
+
+	return buf.Bytes(), nil
 }
 
 // UnmarshalBinary implements the MichelineNode interface
-func (m *MichelinePrim) UnmarshalBinary([]byte) error {
-	panic("not implemented")
+func (m *MichelinePrim) UnmarshalBinary(data []byte) error {
+	node, _, err := UnmarshalMicheline(data)
+	if err != nil {
+		return err
+	}
+	prim, ok := node.(*MichelinePrim)
+	if !ok {
+		return xerrors.Errorf("expected a Micheline primitive, saw %T", node)
+	}
+	*m = *prim
+	return nil
 }
 
 // MichelineSeq represents a sequence of nodes in a Micheline expression
@@ -117,10 +248,226 @@ func (*MichelineSeq) isMichelineNode() {}
 
 // MarshalBinary implements the MichelineNode interface
 func (m MichelineSeq) MarshalBinary() ([]byte, error) {
-	panic("not implemented")
+	elements := new(bytes.Buffer)
+	for i, node := range m {
+		nodeBytes, err := node.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal sequence element %d: %w", i, err)
+		}
+		elements.Write(nodeBytes)
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteByte(michelineTagSeq)
+	err := binary.Write(buf, binary.BigEndian, uint32(elements.Len()))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal sequence length: %w", err)
+	}
+	buf.Write(elements.Bytes())
+	return buf.Bytes(), nil
 }
 
 // UnmarshalBinary implements the MichelineNode interface
-func (m *MichelineSeq) UnmarshalBinary([]byte) error {
-	panic("not implemented")
+func (m *MichelineSeq) UnmarshalBinary(data []byte) error {
+	payload, err := readMichelineLengthPrefixed(data, michelineTagSeq)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal Micheline sequence: %w", err)
+	}
+	elements, err := unmarshalMichelineNodes(payload)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal Micheline sequence: %w", err)
+	}
+	*m = elements
+	return nil
+}
+
+// UnmarshalMicheline decodes the Micheline node at the start of data, dispatching
+// on its tag, and returns it along with the number of bytes it occupies. Trailing
+// bytes are ignored.
+func UnmarshalMicheline(data []byte) (MichelineNode, int, error) {
+	if len(data) == 0 {
+		return nil, 0, xerrors.New("no bytes left to unmarshal a Micheline node")
+	}
+	switch data[0] {
+	case michelineTagInt:
+		value, bytesRead, err := zarith.ReadNextSigned(data[1:])
+		if err != nil {
+			return nil, 0, xerrors.Errorf("failed to unmarshal Micheline int: %w", err)
+		}
+		node := MichelineInt(*value)
+		return &node, 1 + bytesRead, nil
+	case michelineTagString:
+		node := new(MichelineString)
+		if err := node.UnmarshalBinary(data); err != nil {
+			return nil, 0, err
+		}
+		return node, 5 + len(*node), nil
+	case michelineTagBytes:
+		node := new(MichelineBytes)
+		if err := node.UnmarshalBinary(data); err != nil {
+			return nil, 0, err
+		}
+		return node, 5 + len(*node), nil
+	case michelineTagSeq:
+		node := new(MichelineSeq)
+		if err := node.UnmarshalBinary(data); err != nil {
+			return nil, 0, err
+		}
+		// the sequence's payload length can't be recovered from its elements
+		return node, 5 + int(binary.BigEndian.Uint32(data[1:5])), nil
+	case michelineTagPrim0, michelineTagPrim0A, michelineTagPrim1, michelineTagPrim1A,
+		michelineTagPrim2, michelineTagPrim2A, michelineTagApplication:
+		return unmarshalMichelinePrim(data)
+	default:
+		return nil, 0, xerrors.Errorf("unknown Micheline tag %d", data[0])
+	}
+}
+
+// unmarshalMichelinePrim decodes a primitive application in any of its encodings
+func unmarshalMichelinePrim(data []byte) (*MichelinePrim, int, error) {
+	if len(data) < 2 {
+		return nil, 0, xerrors.New("too few bytes to unmarshal Micheline primitive")
+	}
+	tag := data[0]
+	prim := &MichelinePrim{Prim: data[1]}
+	dataPtr := data[2:]
+
+	// arguments
+	if tag == michelineTagApplication {
+		argsBytes, err := readLengthPrefixed(dataPtr)
+		if err != nil {
+			return nil, 0, xerrors.Errorf("failed to unmarshal primitive arguments: %w", err)
+		}
+		prim.Args, err = unmarshalMichelineNodes(argsBytes)
+		if err != nil {
+			return nil, 0, xerrors.Errorf("failed to unmarshal primitive arguments: %w", err)
+		}
+		dataPtr = dataPtr[4+len(argsBytes):]
+	} else {
+		numArgs := int(tag-michelineTagPrim0) / 2
+		for i := 0; i < numArgs; i++ {
+			arg, bytesRead, err := UnmarshalMicheline(dataPtr)
+			if err != nil {
+				return nil, 0, xerrors.Errorf("failed to unmarshal primitive argument %d: %w", i, err)
+			}
+			prim.Args = append(prim.Args, arg)
+			dataPtr = dataPtr[bytesRead:]
+		}
+	}
+
+	// annotations. The tags with annotations are the even ones, plus the general
+	// application form, which always carries them.
+	if (tag-michelineTagPrim0)%2 == 1 || tag == michelineTagApplication {
+		annots, err := readLengthPrefixed(dataPtr)
+		if err != nil {
+			return nil, 0, xerrors.Errorf("failed to unmarshal primitive annotations: %w", err)
+		}
+		if len(annots) > 0 {
+			prim.Annots = strings.Split(string(annots), " ")
+		}
+		dataPtr = dataPtr[4+len(annots):]
+	}
+
+	return prim, len(data) - len(dataPtr), nil
+}
+
+// unmarshalMichelineNodes decodes data as a concatenation of Micheline nodes
+func unmarshalMichelineNodes(data []byte) ([]MichelineNode, error) {
+	nodes := []MichelineNode{}
+	for len(data) > 0 {
+		node, bytesRead, err := UnmarshalMicheline(data)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to unmarshal element %d: %w", len(nodes), err)
+		}
+		nodes = append(nodes, node)
+		data = data[bytesRead:]
+	}
+	return nodes, nil
+}
+
+// MichelineSome returns the Michelson data constructor Some wrapping value
+func MichelineSome(value MichelineNode) *MichelinePrim {
+	return &MichelinePrim{Prim: PrimD_Some, Args: []MichelineNode{value}}
+}
+
+// MichelineNone returns the Michelson data constructor None
+func MichelineNone() *MichelinePrim {
+	return &MichelinePrim{Prim: PrimD_None}
+}
+
+// MichelineLeft returns the Michelson data constructor Left wrapping value
+func MichelineLeft(value MichelineNode) *MichelinePrim {
+	return &MichelinePrim{Prim: PrimD_Left, Args: []MichelineNode{value}}
+}
+
+// MichelineRight returns the Michelson data constructor Right wrapping value
+func MichelineRight(value MichelineNode) *MichelinePrim {
+	return &MichelinePrim{Prim: PrimD_Right, Args: []MichelineNode{value}}
+}
+
+// MichelinePair returns the right comb of pairs holding nodes, i.e.
+// Pair nodes[0] (Pair nodes[1] (... (Pair nodes[n-2] nodes[n-1]))). The pairs are
+// always nested rather than using the multi-argument Pair form, which older
+// protocols do not accept. It errors if fewer than two nodes are given.
+func MichelinePair(nodes ...MichelineNode) (MichelineNode, error) {
+	if len(nodes) < 2 {
+		return nil, xerrors.Errorf("a Micheline pair needs at least two elements, saw %d", len(nodes))
+	}
+	pair := &MichelinePrim{Prim: PrimD_Pair, Args: []MichelineNode{nodes[len(nodes)-2], nodes[len(nodes)-1]}}
+	for i := len(nodes) - 3; i >= 0; i-- {
+		pair = &MichelinePrim{Prim: PrimD_Pair, Args: []MichelineNode{nodes[i], pair}}
+	}
+	return pair, nil
+}
+
+// FlattenMichelinePair returns the elements of a right comb of pairs, in order. It
+// accepts both the nested and the multi-argument Pair forms. A node that is not a
+// pair is returned as the only element.
+func FlattenMichelinePair(node MichelineNode) []MichelineNode {
+	pair, ok := node.(*MichelinePrim)
+	if !ok || pair.Prim != PrimD_Pair || len(pair.Args) < 2 {
+		return []MichelineNode{node}
+	}
+	elements := append([]MichelineNode{}, pair.Args[:len(pair.Args)-1]...)
+	return append(elements, FlattenMichelinePair(pair.Args[len(pair.Args)-1])...)
+}
+
+// MichelineElt is one key/value binding of a Michelson map or big_map
+type MichelineElt struct {
+	Key   MichelineNode
+	Value MichelineNode
+}
+
+// MichelineToList returns the elements of a Michelson list, which is encoded as
+// a Micheline sequence
+func MichelineToList(node MichelineNode) ([]MichelineNode, error) {
+	seq, ok := node.(*MichelineSeq)
+	if !ok {
+		return nil, xerrors.Errorf("expected a Micheline sequence, saw %T", node)
+	}
+	return []MichelineNode(*seq), nil
+}
+
+// MichelineToSet returns the elements of a Michelson set, which is encoded as a
+// Micheline sequence of its elements in increasing order
+func MichelineToSet(node MichelineNode) ([]MichelineNode, error) {
+	return MichelineToList(node)
+}
+
+// MichelineToMap returns the bindings of a Michelson map, which is encoded as a
+// Micheline sequence of Elt primitives. The bindings are returned as a slice, in
+// order, since Micheline nodes cannot be compared as Go map keys.
+func MichelineToMap(node MichelineNode) ([]MichelineElt, error) {
+	elements, err := MichelineToList(node)
+	if err != nil {
+		return nil, err
+	}
+	elts := make([]MichelineElt, 0, len(elements))
+	for i, element := range elements {
+		elt, ok := element.(*MichelinePrim)
+		if !ok || elt.Prim != PrimD_Elt || len(elt.Args) != 2 {
+			return nil, xerrors.Errorf("map element %d is not an Elt with a key and a value", i)
+		}
+		elts = append(elts, MichelineElt{Key: elt.Args[0], Value: elt.Args[1]})
+	}
+	return elts, nil
 }
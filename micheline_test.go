@@ -1,6 +1,8 @@
 package tezosprotocol_test
 
 import (
+	"encoding/hex"
+	"math/big"
 	"testing"
 
 	tezosprotocol "github.com/anchorageoss/tezosprotocol/v3"
@@ -24,10 +26,34 @@ func TestMichelineEncodings(t *testing.T) {
 			name: "short string",
 			node: (*tezosprotocol.MichelineString)(&shortString),
 			want: []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x61},
+		}, {
+			name: "empty bytes",
+			node: &tezosprotocol.MichelineBytes{},
+			want: []byte{0xa, 0x0, 0x0, 0x0, 0x0},
+		}, {
+			name: "bytes",
+			node: &tezosprotocol.MichelineBytes{0x1, 0x2, 0x3},
+			want: []byte{0xa, 0x0, 0x0, 0x0, 0x3, 0x1, 0x2, 0x3},
 		}, {
 			name: "prim0",
 			node: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_unit},
 			want: []byte{0x3, 0x6c},
+		}, {
+			name: "None",
+			node: tezosprotocol.MichelineNone(),
+			want: []byte{0x3, 0x6},
+		}, {
+			name: "Pair",
+			node: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{michelineString("a"), &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit}}},
+			want: []byte{0x7, 0x7, 0x1, 0x0, 0x0, 0x0, 0x1, 0x61, 0x3, 0xb},
+		}, {
+			name: "entrypoint annotation",
+			node: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_unit, Annots: []string{"%transfer"}},
+			want: []byte{0x4, 0x6c, 0x0, 0x0, 0x0, 0x9, 0x25, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72},
+		}, {
+			name: "three argument Pair",
+			node: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{michelineInt(1), michelineInt(2), michelineInt(3)}},
+			want: []byte{0x9, 0x7, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0},
 		},
 	}
 	for _, tt := range tests {
@@ -42,3 +68,189 @@ func TestMichelineEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestMichelineBytesUnmarshal(t *testing.T) {
+	require := require.New(t)
+	var node tezosprotocol.MichelineBytes
+	require.NoError(node.UnmarshalBinary([]byte{0xa, 0x0, 0x0, 0x0, 0x3, 0x1, 0x2, 0x3}))
+	require.Equal(tezosprotocol.MichelineBytes{0x1, 0x2, 0x3}, node)
+
+	// short buffer
+	err := node.UnmarshalBinary([]byte{0xa, 0x0, 0x0, 0x0, 0x3, 0x1, 0x2})
+	require.Error(err)
+	require.Contains(err.Error(), "node declares 3 bytes but only 2 remain")
+
+	// wrong tag
+	require.Error(node.UnmarshalBinary([]byte{0x1, 0x0, 0x0, 0x0, 0x0}))
+}
+
+func TestMichelineSeqRoundTrip(t *testing.T) {
+	require := require.New(t)
+	// { {} ; { "a" ; Unit } }
+	a := tezosprotocol.MichelineString("a")
+	seq := tezosprotocol.MichelineSeq{
+		&tezosprotocol.MichelineSeq{},
+		&tezosprotocol.MichelineSeq{&a, &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit}},
+	}
+	encoded, err := seq.MarshalBinary()
+	require.NoError(err)
+	require.Equal([]byte{
+		0x2, 0x0, 0x0, 0x0, 0x12,
+		0x2, 0x0, 0x0, 0x0, 0x0,
+		0x2, 0x0, 0x0, 0x0, 0x8,
+		0x1, 0x0, 0x0, 0x0, 0x1, 0x61,
+		0x3, 0xb,
+	}, encoded)
+
+	var decoded tezosprotocol.MichelineSeq
+	require.NoError(decoded.UnmarshalBinary(encoded))
+	require.Equal(seq, decoded)
+
+	// an element overrunning the sequence
+	encoded[4]--
+	require.Error(decoded.UnmarshalBinary(encoded[:len(encoded)-1]))
+}
+
+func TestUnmarshalMicheline(t *testing.T) {
+	unit := &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit}
+	tests := []struct {
+		name string
+		data string
+		want tezosprotocol.MichelineNode
+	}{
+		{name: "int", data: "00c101", want: michelineInt(-65)},
+		{name: "string", data: "010000000161", want: michelineString("a")},
+		{name: "seq", data: "0200000002030b", want: &tezosprotocol.MichelineSeq{unit}},
+		{name: "prim0", data: "030b", want: unit},
+		{
+			name: "prim0 with annots",
+			data: "040b0000000440782025", // Unit @x %
+			want: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit, Annots: []string{"@x", "%"}},
+		}, {
+			name: "prim1",
+			data: "05090001", // Some 1
+			want: tezosprotocol.MichelineSome(michelineInt(1)),
+		}, {
+			name: "prim1 with annots",
+			data: "0605030b000000022561", // Left %a Unit
+			want: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Left, Args: []tezosprotocol.MichelineNode{unit}, Annots: []string{"%a"}},
+		}, {
+			name: "prim2",
+			data: "07070001010000000161", // Pair 1 "a"
+			want: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{michelineInt(1), michelineString("a")}},
+		}, {
+			name: "prim2 with annots",
+			data: "080700010002000000022570", // Pair %p 1 2
+			want: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{michelineInt(1), michelineInt(2)}, Annots: []string{"%p"}},
+		}, {
+			name: "application",
+			data: "09070000000600010002000300000000", // Pair 1 2 3
+			want: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{michelineInt(1), michelineInt(2), michelineInt(3)}},
+		},
+		{name: "bytes", data: "0a00000002cafe", want: &tezosprotocol.MichelineBytes{0xca, 0xfe}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			data, err := hex.DecodeString(tt.data)
+			require.NoError(err)
+			got, bytesRead, err := tezosprotocol.UnmarshalMicheline(append(data, 0xff))
+			require.NoError(err)
+			require.Equal(tt.want, got)
+			require.Equal(len(data), bytesRead)
+			reencoded, err := got.MarshalBinary()
+			require.NoError(err)
+			require.Equal(data, reencoded)
+
+			// truncated input
+			_, _, err = tezosprotocol.UnmarshalMicheline(data[:len(data)-1])
+			require.Error(err)
+		})
+	}
+
+	_, _, err := tezosprotocol.UnmarshalMicheline([]byte{0x0b})
+	require.Error(t, err)
+}
+
+func TestMichelineOptionAndOrConstructors(t *testing.T) {
+	require := require.New(t)
+	value := tezosprotocol.MichelineString("a")
+	for prim, node := range map[byte]*tezosprotocol.MichelinePrim{
+		tezosprotocol.PrimD_Some:  tezosprotocol.MichelineSome(&value),
+		tezosprotocol.PrimD_Left:  tezosprotocol.MichelineLeft(&value),
+		tezosprotocol.PrimD_Right: tezosprotocol.MichelineRight(&value),
+	} {
+		require.Equal(prim, node.Prim)
+		require.Equal([]tezosprotocol.MichelineNode{&value}, node.Args)
+		require.Empty(node.Annots)
+	}
+	require.Equal(tezosprotocol.PrimD_None, tezosprotocol.MichelineNone().Prim)
+	require.Empty(tezosprotocol.MichelineNone().Args)
+}
+
+func TestMichelinePair(t *testing.T) {
+	require := require.New(t)
+	a, b, c, d := tezosprotocol.MichelineString("a"), tezosprotocol.MichelineString("b"), tezosprotocol.MichelineString("c"), tezosprotocol.MichelineString("d")
+	pair := func(left, right tezosprotocol.MichelineNode) *tezosprotocol.MichelinePrim {
+		return &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{left, right}}
+	}
+
+	for _, tt := range []struct {
+		elements []tezosprotocol.MichelineNode
+		want     tezosprotocol.MichelineNode
+	}{
+		{[]tezosprotocol.MichelineNode{&a, &b}, pair(&a, &b)},
+		{[]tezosprotocol.MichelineNode{&a, &b, &c}, pair(&a, pair(&b, &c))},
+		{[]tezosprotocol.MichelineNode{&a, &b, &c, &d}, pair(&a, pair(&b, pair(&c, &d)))},
+	} {
+		observed, err := tezosprotocol.MichelinePair(tt.elements...)
+		require.NoError(err)
+		require.Equal(tt.want, observed)
+		require.Equal(tt.elements, tezosprotocol.FlattenMichelinePair(observed))
+	}
+	_, err := tezosprotocol.MichelinePair(&a)
+	require.Error(err)
+	_, err = tezosprotocol.MichelinePair()
+	require.Error(err)
+
+	elements := []tezosprotocol.MichelineNode{&a, &b, &c, &d}
+	multiArgPair := &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Pair, Args: []tezosprotocol.MichelineNode{&a, &b, pair(&c, &d)}}
+	require.Equal(elements, tezosprotocol.FlattenMichelinePair(multiArgPair))
+	require.Equal([]tezosprotocol.MichelineNode{&a}, tezosprotocol.FlattenMichelinePair(&a))
+}
+
+func TestMichelineToCollections(t *testing.T) {
+	require := require.New(t)
+	one, two := tezosprotocol.MichelineInt(*big.NewInt(1)), tezosprotocol.MichelineInt(*big.NewInt(2))
+	list := &tezosprotocol.MichelineSeq{&one, &two}
+	elements, err := tezosprotocol.MichelineToList(list)
+	require.NoError(err)
+	require.Equal([]tezosprotocol.MichelineNode{&one, &two}, elements)
+	elements, err = tezosprotocol.MichelineToSet(list)
+	require.NoError(err)
+	require.Equal([]tezosprotocol.MichelineNode{&one, &two}, elements)
+
+	a, b := tezosprotocol.MichelineString("a"), tezosprotocol.MichelineString("b")
+	elt := func(key, value tezosprotocol.MichelineNode) *tezosprotocol.MichelinePrim {
+		return &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Elt, Args: []tezosprotocol.MichelineNode{key, value}}
+	}
+	bindings, err := tezosprotocol.MichelineToMap(&tezosprotocol.MichelineSeq{elt(&a, &one), elt(&b, &two)})
+	require.NoError(err)
+	require.Equal([]tezosprotocol.MichelineElt{{Key: &a, Value: &one}, {Key: &b, Value: &two}}, bindings)
+
+	_, err = tezosprotocol.MichelineToList(&a)
+	require.Error(err)
+	_, err = tezosprotocol.MichelineToMap(list)
+	require.Error(err)
+}
+
+func michelineInt(value int64) *tezosprotocol.MichelineInt {
+	node := tezosprotocol.MichelineInt(*big.NewInt(value))
+	return &node
+}
+
+func michelineString(value string) *tezosprotocol.MichelineString {
+	node := tezosprotocol.MichelineString(value)
+	return &node
+}
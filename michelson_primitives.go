@@ -1,5 +1,11 @@
 package tezosprotocol
 
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
 const (
 	// PrimK_parameter and the remaining constants are adapted from Adapted from
 	// https://gitlab.com/tezos/tezos/blob/master/src%2Fproto_alpha%2Flib_protocol%2Fmichelson_v1_primitives.ml
@@ -124,4 +130,239 @@ const (
 	PrimI_APPLY
 	PrimT_chain_id
 	PrimI_CHAIN_ID
+	PrimI_LEVEL
+	PrimI_SELF_ADDRESS
+	PrimT_never
+	PrimI_NEVER
+	PrimI_UNPAIR
+	PrimI_VOTING_POWER
+	PrimI_TOTAL_VOTING_POWER
+	PrimI_KECCAK
+	PrimI_SHA3
+	PrimI_PAIRING_CHECK
+	PrimT_bls12_381_g1
+	PrimT_bls12_381_g2
+	PrimT_bls12_381_fr
+	PrimT_sapling_state
+	PrimT_sapling_transaction_deprecated
+	PrimI_SAPLING_EMPTY_STATE
+	PrimI_SAPLING_VERIFY_UPDATE
+	PrimT_ticket
+	PrimI_TICKET_DEPRECATED
+	PrimI_READ_TICKET
+	PrimI_SPLIT_TICKET
+	PrimI_JOIN_TICKETS
+	PrimI_GET_AND_UPDATE
+	PrimT_chest
+	PrimT_chest_key
+	PrimI_OPEN_CHEST
+	PrimI_VIEW
+	PrimK_view
+	PrimH_constant
+	PrimI_SUB_MUTEZ
+	PrimT_tx_rollup_l2_address
+	PrimI_MIN_BLOCK_TIME
+	PrimT_sapling_transaction
+	PrimI_EMIT
+	PrimD_Lambda_rec
+	PrimI_LAMBDA_REC
+	PrimI_TICKET
+	PrimI_BYTES
+	PrimI_NAT
+	PrimD_Ticket
 )
+
+// Prim is a Michelson primitive, i.e. a keyword, data constructor, instruction or
+// type, identified by its byte value in the Micheline binary encoding. The Prim*
+// constants hold the values of all the primitives known to this package.
+type Prim byte
+
+// primNames holds the Michelson name of each primitive, indexed by its byte value
+var primNames = [...]string{
+	"parameter",
+	"storage",
+	"code",
+	"False",
+	"Elt",
+	"Left",
+	"None",
+	"Pair",
+	"Right",
+	"Some",
+	"True",
+	"Unit",
+	"PACK",
+	"UNPACK",
+	"BLAKE2B",
+	"SHA256",
+	"SHA512",
+	"ABS",
+	"ADD",
+	"AMOUNT",
+	"AND",
+	"BALANCE",
+	"CAR",
+	"CDR",
+	"CHECK_SIGNATURE",
+	"COMPARE",
+	"CONCAT",
+	"CONS",
+	"CREATE_ACCOUNT",
+	"CREATE_CONTRACT",
+	"IMPLICIT_ACCOUNT",
+	"DIP",
+	"DROP",
+	"DUP",
+	"EDIV",
+	"EMPTY_MAP",
+	"EMPTY_SET",
+	"EQ",
+	"EXEC",
+	"FAILWITH",
+	"GE",
+	"GET",
+	"GT",
+	"HASH_KEY",
+	"IF",
+	"IF_CONS",
+	"IF_LEFT",
+	"IF_NONE",
+	"INT",
+	"LAMBDA",
+	"LE",
+	"LEFT",
+	"LOOP",
+	"LSL",
+	"LSR",
+	"LT",
+	"MAP",
+	"MEM",
+	"MUL",
+	"NEG",
+	"NEQ",
+	"NIL",
+	"NONE",
+	"NOT",
+	"NOW",
+	"OR",
+	"PAIR",
+	"PUSH",
+	"RIGHT",
+	"SIZE",
+	"SOME",
+	"SOURCE",
+	"SENDER",
+	"SELF",
+	"STEPS_TO_QUOTA",
+	"SUB",
+	"SWAP",
+	"TRANSFER_TOKENS",
+	"SET_DELEGATE",
+	"UNIT",
+	"UPDATE",
+	"XOR",
+	"ITER",
+	"LOOP_LEFT",
+	"ADDRESS",
+	"CONTRACT",
+	"ISNAT",
+	"CAST",
+	"RENAME",
+	"bool",
+	"contract",
+	"int",
+	"key",
+	"key_hash",
+	"lambda",
+	"list",
+	"map",
+	"big_map",
+	"nat",
+	"option",
+	"or",
+	"pair",
+	"set",
+	"signature",
+	"string",
+	"bytes",
+	"mutez",
+	"timestamp",
+	"unit",
+	"operation",
+	"address",
+	"SLICE",
+	"DIG",
+	"DUG",
+	"EMPTY_BIG_MAP",
+	"APPLY",
+	"chain_id",
+	"CHAIN_ID",
+	"LEVEL",
+	"SELF_ADDRESS",
+	"never",
+	"NEVER",
+	"UNPAIR",
+	"VOTING_POWER",
+	"TOTAL_VOTING_POWER",
+	"KECCAK",
+	"SHA3",
+	"PAIRING_CHECK",
+	"bls12_381_g1",
+	"bls12_381_g2",
+	"bls12_381_fr",
+	"sapling_state",
+	"sapling_transaction_deprecated",
+	"SAPLING_EMPTY_STATE",
+	"SAPLING_VERIFY_UPDATE",
+	"ticket",
+	"TICKET_DEPRECATED",
+	"READ_TICKET",
+	"SPLIT_TICKET",
+	"JOIN_TICKETS",
+	"GET_AND_UPDATE",
+	"chest",
+	"chest_key",
+	"OPEN_CHEST",
+	"VIEW",
+	"view",
+	"constant",
+	"SUB_MUTEZ",
+	"tx_rollup_l2_address",
+	"MIN_BLOCK_TIME",
+	"sapling_transaction",
+	"EMIT",
+	"Lambda_rec",
+	"LAMBDA_REC",
+	"TICKET",
+	"BYTES",
+	"NAT",
+	"Ticket",
+}
+
+// primsByName maps the Michelson name of each primitive to its byte value
+var primsByName = func() map[string]byte {
+	prims := make(map[string]byte, len(primNames))
+	for i, name := range primNames {
+		prims[name] = byte(i)
+	}
+	return prims
+}()
+
+// String returns the Michelson name of the primitive, e.g. "unit" for PrimT_unit
+func (p Prim) String() string {
+	if int(p) < len(primNames) {
+		return primNames[p]
+	}
+	return fmt.Sprintf("Prim(0x%02x)", byte(p))
+}
+
+// PrimFromString returns the byte value of the primitive with the given Michelson
+// name. Names are case sensitive: "pair" is the type, "Pair" the data constructor
+// and "PAIR" the instruction.
+func PrimFromString(name string) (byte, error) {
+	prim, ok := primsByName[name]
+	if !ok {
+		return 0, xerrors.Errorf("unknown Michelson primitive %q", name)
+	}
+	return prim, nil
+}
@@ -0,0 +1,34 @@
+package tezosprotocol_test
+
+import (
+	"testing"
+
+	tezosprotocol "github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimNames(t *testing.T) {
+	require := require.New(t)
+	require.Equal(byte(0x6c), tezosprotocol.PrimT_unit)
+	require.Equal("unit", tezosprotocol.Prim(tezosprotocol.PrimT_unit).String())
+	require.Equal("Pair", tezosprotocol.Prim(tezosprotocol.PrimD_Pair).String())
+	require.Equal("PAIR", tezosprotocol.Prim(tezosprotocol.PrimI_PAIR).String())
+	require.Equal("Ticket", tezosprotocol.Prim(0x9d).String())
+	require.Equal("Prim(0xff)", tezosprotocol.Prim(0xff).String())
+
+	prim, err := tezosprotocol.PrimFromString("pair")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrimT_pair, prim)
+	prim, err = tezosprotocol.PrimFromString("CHAIN_ID")
+	require.NoError(err)
+	require.Equal(byte(0x75), prim)
+	_, err = tezosprotocol.PrimFromString("PAIRR")
+	require.Error(err)
+
+	// every named primitive round-trips
+	for i := 0; i <= int(tezosprotocol.PrimD_Ticket); i++ {
+		prim, err := tezosprotocol.PrimFromString(tezosprotocol.Prim(i).String())
+		require.NoError(err)
+		require.Equal(byte(i), prim)
+	}
+}
@@ -0,0 +1,92 @@
+package tezosprotocol
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed" // for the BIP39 word list
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/xerrors"
+)
+
+// BIP39 seed derivation parameters.
+// Reference: https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki#from-mnemonic-to-seed
+const (
+	bip39SeedIterations = 2048
+	bip39SeedLen        = 64
+	bip39SaltPrefix     = "mnemonic"
+	bip39BitsPerWord    = 11
+)
+
+// bip39EnglishWordList is the English BIP39 word list, one word per line.
+// Reference: https://github.com/bitcoin/bips/blob/master/bip-0039/english.txt
+//
+//go:embed bip39_english.txt
+var bip39EnglishWordList string
+
+// bip39EnglishWordIndexes maps each word of the English BIP39 word list to its index
+var bip39EnglishWordIndexes = func() map[string]int64 {
+	indexes := map[string]int64{}
+	for i, word := range strings.Fields(bip39EnglishWordList) {
+		indexes[word] = int64(i)
+	}
+	return indexes
+}()
+
+// NewPrivateKeySeedFromMnemonic derives an ed25519 private key seed from a BIP39
+// mnemonic and an optional passphrase, the way tezos wallets (and the fundraiser)
+// do: the seed is the first 32 bytes of the BIP39 seed. The mnemonic must have 12,
+// 15, 18, 21 or 24 words from the English BIP39 word list and a valid checksum.
+// The mnemonic and passphrase are NFKD normalized before derivation.
+func NewPrivateKeySeedFromMnemonic(mnemonic, passphrase string) (PrivateKeySeed, error) {
+	bip39Seed, err := newBIP39Seed(mnemonic, passphrase)
+	if err != nil {
+		return "", err
+	}
+	seed, err := Base58CheckEncode(PrefixEd25519Seed, bip39Seed[:32])
+	if err != nil {
+		return "", xerrors.Errorf("failed to encode seed: %w", err)
+	}
+	return PrivateKeySeed(seed), nil
+}
+
+// newBIP39Seed returns the 64-byte BIP39 seed of a mnemonic and passphrase
+func newBIP39Seed(mnemonic, passphrase string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, xerrors.Errorf("invalid mnemonic: expected 12, 15, 18, 21 or 24 words, saw %d", len(words))
+	}
+	if err := validateBIP39Checksum(words); err != nil {
+		return nil, err
+	}
+	salt := bip39SaltPrefix + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(strings.Join(words, " ")), []byte(salt), bip39SeedIterations, bip39SeedLen, sha512.New), nil
+}
+
+// validateBIP39Checksum checks that words are in the English BIP39 word list and
+// that their last bits are the checksum of the entropy they encode.
+// Reference: https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki#generating-the-mnemonic
+func validateBIP39Checksum(words []string) error {
+	bits := new(big.Int)
+	for _, word := range words {
+		index, ok := bip39EnglishWordIndexes[word]
+		if !ok {
+			return xerrors.Errorf("invalid mnemonic: %q is not in the BIP39 word list", word)
+		}
+		bits.Lsh(bits, bip39BitsPerWord)
+		bits.Or(bits, big.NewInt(index))
+	}
+	// every 32 bits of entropy add one bit of checksum
+	checksumLen := uint(len(words) * bip39BitsPerWord / 33)
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumLen-1))
+	entropy := make([]byte, len(words)*bip39BitsPerWord/33*4)
+	new(big.Int).Rsh(bits, checksumLen).FillBytes(entropy)
+	entropyHash := sha256.Sum256(entropy)
+	if expected := int64(entropyHash[0] >> (8 - checksumLen)); checksum.Int64() != expected {
+		return xerrors.New("invalid mnemonic: checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package tezosprotocol_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrivateKeySeedFromMnemonic(t *testing.T) {
+	require := require.New(t)
+	// BIP39 test vector: the seed is c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e5349553...
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+	seed, err := tezosprotocol.NewPrivateKeySeedFromMnemonic(mnemonic, "TREZOR")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrivateKeySeed("edsk4AobYA4B3RDD6fdr9GChkU9dBsMAm46nWZawx5hHV4PUkKhQJK"), seed)
+	_, err = seed.PrivateKey()
+	require.NoError(err)
+
+	// extra whitespace is ignored
+	seed, err = tezosprotocol.NewPrivateKeySeedFromMnemonic("  "+strings.ReplaceAll(mnemonic, " ", "\n  ")+" ", "TREZOR")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrivateKeySeed("edsk4AobYA4B3RDD6fdr9GChkU9dBsMAm46nWZawx5hHV4PUkKhQJK"), seed)
+
+	// BIP39 test vector: the seed is bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd30971...
+	seed, err = tezosprotocol.NewPrivateKeySeedFromMnemonic(strings.Repeat("abandon ", 23)+"art", "TREZOR")
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrivateKeySeed("edsk47RpuPkW9a5pSqyQ4NXf5VwohQ7Z4gixjdg9X9dFxMqc8otrpA"), seed)
+
+	_, err = tezosprotocol.NewPrivateKeySeedFromMnemonic(strings.Repeat("abandon ", 13)+"about", "")
+	require.Error(err)
+	_, err = tezosprotocol.NewPrivateKeySeedFromMnemonic("", "")
+	require.Error(err)
+}
+
+func TestNewPrivateKeySeedFromMnemonicValidation(t *testing.T) {
+	require := require.New(t)
+	// the checksum of 128 zero bits is 0011, so the last word must be "about"
+	_, err := tezosprotocol.NewPrivateKeySeedFromMnemonic(strings.Repeat("abandon ", 12), "")
+	require.Error(err)
+	require.Contains(err.Error(), "checksum")
+	_, err = tezosprotocol.NewPrivateKeySeedFromMnemonic(strings.Repeat("abandon ", 11)+"tezos", "")
+	require.Error(err)
+	require.Contains(err.Error(), "word list")
+}
+
+func TestNewPrivateKeySeedFromMnemonicNormalization(t *testing.T) {
+	require := require.New(t)
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+	expected, err := tezosprotocol.NewPrivateKeySeedFromMnemonic(mnemonic, "caf\u00e9")
+	require.NoError(err)
+
+	// the passphrase is decomposed, so precomposed and combining accents are equivalent
+	seed, err := tezosprotocol.NewPrivateKeySeedFromMnemonic(mnemonic, "cafe\u0301")
+	require.NoError(err)
+	require.Equal(expected, seed)
+	seed, err = tezosprotocol.NewPrivateKeySeedFromMnemonic(mnemonic, "cafe")
+	require.NoError(err)
+	require.NotEqual(expected, seed)
+
+	// compatibility characters in the mnemonic are folded
+	fullwidth := strings.Repeat("\uff41\uff42\uff41\uff4e\uff44\uff4f\uff4e ", 11) + "about"
+	seed, err = tezosprotocol.NewPrivateKeySeedFromMnemonic(fullwidth, "caf\u00e9")
+	require.NoError(err)
+	require.Equal(expected, seed)
+}
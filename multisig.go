@@ -0,0 +1,114 @@
+package tezosprotocol
+
+import (
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+// The generic multisig contract takes an action of type
+//   or (lambda %operation unit (list operation)) (pair %change_keys nat (list key))
+// and runs the lambda to produce the operations to emit. The helpers below build
+// the Left lambda for the common actions exactly as tezos-client does for the same
+// contract, with addresses and key hashes pushed in their optimized binary form and
+// macros expanded, so that the packed actions match byte for byte.
+// Reference: https://github.com/murbard/smart-contracts/blob/master/multisig/michelson/generic.tz
+
+// MultisigTransferAction returns the multisig action that transfers amountMutez
+// from the multisig contract to the given contract. Transfers to an originated
+// contract call its default entrypoint with Unit.
+func MultisigTransferAction(to ContractID, amountMutez *big.Int) (MichelineNode, error) {
+	if amountMutez == nil || amountMutez.Sign() < 0 {
+		return nil, xerrors.Errorf("invalid transfer amount %v", amountMutez)
+	}
+	accountType, err := to.AccountType()
+	if err != nil {
+		return nil, xerrors.Errorf("invalid transfer destination: %w", err)
+	}
+	amount := MichelineInt(*amountMutez)
+	var pushDestination []MichelineNode
+	switch accountType {
+	case AccountTypeImplicit:
+		destinationBytes, err := to.EncodePubKeyHash()
+		if err != nil {
+			return nil, xerrors.Errorf("invalid transfer destination: %w", err)
+		}
+		destination := MichelineBytes(destinationBytes)
+		// PUSH key_hash 0x... ; IMPLICIT_ACCOUNT
+		pushDestination = []MichelineNode{
+			michelinePrim(PrimI_PUSH, michelinePrim(PrimT_key_hash), &destination),
+			michelinePrim(PrimI_IMPLICIT_ACCOUNT),
+		}
+	default:
+		destinationBytes, err := to.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("invalid transfer destination: %w", err)
+		}
+		destination := MichelineBytes(destinationBytes)
+		// PUSH address 0x... ; CONTRACT unit ; ASSERT_SOME, where ASSERT_SOME expands
+		// to { IF_NONE { { UNIT ; FAILWITH } } {} }
+		pushDestination = []MichelineNode{
+			michelinePrim(PrimI_PUSH, michelinePrim(PrimT_address), &destination),
+			michelinePrim(PrimI_CONTRACT, michelinePrim(PrimT_unit)),
+			&MichelineSeq{
+				michelinePrim(PrimI_IF_NONE,
+					&MichelineSeq{&MichelineSeq{michelinePrim(PrimI_UNIT), michelinePrim(PrimI_FAILWITH)}},
+					&MichelineSeq{}),
+			},
+		}
+	}
+	lambda := MichelineSeq{
+		michelinePrim(PrimI_DROP),
+		michelinePrim(PrimI_NIL, michelinePrim(PrimT_operation)),
+	}
+	lambda = append(lambda, pushDestination...)
+	lambda = append(lambda,
+		michelinePrim(PrimI_PUSH, michelinePrim(PrimT_mutez), &amount),
+		michelinePrim(PrimI_UNIT),
+		michelinePrim(PrimI_TRANSFER_TOKENS),
+		michelinePrim(PrimI_CONS),
+	)
+	return MichelineLeft(&lambda), nil
+}
+
+// MultisigSetDelegateAction returns the multisig action that sets the multisig
+// contract's delegate. A nil delegate withdraws the current delegation.
+func MultisigSetDelegateAction(delegate *ContractID) (MichelineNode, error) {
+	var pushDelegate []MichelineNode
+	if delegate == nil {
+		// NONE key_hash
+		pushDelegate = []MichelineNode{michelinePrim(PrimI_NONE, michelinePrim(PrimT_key_hash))}
+	} else {
+		accountType, err := delegate.AccountType()
+		if err != nil {
+			return nil, xerrors.Errorf("invalid delegate: %w", err)
+		}
+		if accountType != AccountTypeImplicit {
+			return nil, xerrors.Errorf("delegate %s must be an implicit account", *delegate)
+		}
+		delegateBytes, err := delegate.EncodePubKeyHash()
+		if err != nil {
+			return nil, xerrors.Errorf("invalid delegate: %w", err)
+		}
+		delegateKeyHash := MichelineBytes(delegateBytes)
+		// PUSH key_hash 0x... ; SOME
+		pushDelegate = []MichelineNode{
+			michelinePrim(PrimI_PUSH, michelinePrim(PrimT_key_hash), &delegateKeyHash),
+			michelinePrim(PrimI_SOME),
+		}
+	}
+	lambda := MichelineSeq{
+		michelinePrim(PrimI_DROP),
+		michelinePrim(PrimI_NIL, michelinePrim(PrimT_operation)),
+	}
+	lambda = append(lambda, pushDelegate...)
+	lambda = append(lambda,
+		michelinePrim(PrimI_SET_DELEGATE),
+		michelinePrim(PrimI_CONS),
+	)
+	return MichelineLeft(&lambda), nil
+}
+
+func michelinePrim(prim byte, args ...MichelineNode) *MichelinePrim {
+	return &MichelinePrim{Prim: prim, Args: args}
+}
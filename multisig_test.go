@@ -0,0 +1,64 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// packedAction returns the hex of action as packed by the PACK instruction
+func packedAction(t *testing.T, action tezosprotocol.MichelineNode) string {
+	actionBytes, err := action.MarshalBinary()
+	require.NoError(t, err)
+	return "05" + hex.EncodeToString(actionBytes)
+}
+
+func TestMultisigTransferAction(t *testing.T) {
+	require := require.New(t)
+	// Left { DROP ; NIL operation ; PUSH key_hash 0x00e767...3c ; IMPLICIT_ACCOUNT ;
+	//        PUSH mutez 1000000 ; UNIT ; TRANSFER_TOKENS ; CONS }
+	action, err := tezosprotocol.MultisigTransferAction("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", big.NewInt(1000000))
+	require.NoError(err)
+	require.Equal(
+		"05"+"0505"+"0200000034"+"0320"+"053d036d"+"0743035d0a0000001500e7670f32038107a59a2b9cfefae36ea21f5aa63c"+
+			"031e"+"0743036a0080897a"+"034f"+"034d"+"031b",
+		packedAction(t, action))
+
+	// Left { DROP ; NIL operation ; PUSH address 0x01f234...00 ; CONTRACT unit ;
+	//        { IF_NONE { { UNIT ; FAILWITH } } {} } ;
+	//        PUSH mutez 1000000 ; UNIT ; TRANSFER_TOKENS ; CONS }
+	action, err = tezosprotocol.MultisigTransferAction("KT1WfRb2j1YPot5PR1CRPKowiteVmKGaA5NA", big.NewInt(1000000))
+	require.NoError(err)
+	require.Equal(
+		"05"+"0505"+"0200000051"+"0320"+"053d036d"+"0743036e0a0000001601f2342b8bc076c65f83a286152634e9c172ad08de00"+
+			"0555036c"+"0200000015072f02000000090200000004034f03270200000000"+
+			"0743036a0080897a"+"034f"+"034d"+"031b",
+		packedAction(t, action))
+
+	_, err = tezosprotocol.MultisigTransferAction("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", big.NewInt(-1))
+	require.Error(err)
+}
+
+func TestMultisigSetDelegateAction(t *testing.T) {
+	require := require.New(t)
+	// Left { DROP ; NIL operation ; PUSH key_hash 0x00c55c...bd ; SOME ; SET_DELEGATE ; CONS }
+	delegate := tezosprotocol.ContractID("tz1ddb9NMYHZi5UzPdzTZMYQQZoMub195zgv")
+	action, err := tezosprotocol.MultisigSetDelegateAction(&delegate)
+	require.NoError(err)
+	require.Equal(
+		"05"+"0505"+"020000002a"+"0320"+"053d036d"+"0743035d0a0000001500c55cf02dbeecc978d9c84625dcae72bb77ea4fbd"+
+			"0346"+"034e"+"031b",
+		packedAction(t, action))
+
+	// Left { DROP ; NIL operation ; NONE key_hash ; SET_DELEGATE ; CONS }
+	action, err = tezosprotocol.MultisigSetDelegateAction(nil)
+	require.NoError(err)
+	require.Equal("05"+"0505"+"020000000e"+"0320"+"053d036d"+"053e035d"+"034e"+"031b", packedAction(t, action))
+
+	originated := tezosprotocol.ContractID("KT1WfRb2j1YPot5PR1CRPKowiteVmKGaA5NA")
+	_, err = tezosprotocol.MultisigSetDelegateAction(&originated)
+	require.Error(err)
+}
@@ -0,0 +1,146 @@
+package tezosprotocol
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// MutezPerTez is the number of mutez in one tez
+const MutezPerTez = int64(1000000)
+
+// tezDecimals is the number of decimal places in a tez amount
+const tezDecimals = 6
+
+// ParseMutezString parses an amount as it typically appears in configuration
+// files and RPC responses. A string with a decimal point, e.g. "1.5", is read as
+// tez; any other string, e.g. "1266", is read as a whole number of mutez.
+// Negative amounts and tez amounts with more than 6 decimal places are rejected.
+func ParseMutezString(s string) (*big.Int, error) {
+	if s == "" || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return nil, xerrors.Errorf("invalid amount %q", s)
+	}
+	dot := strings.IndexByte(s, '.')
+	if dot == -1 {
+		mutez, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, xerrors.Errorf("invalid mutez amount %q", s)
+		}
+		return mutez, nil
+	}
+
+	whole, fraction := s[:dot], s[dot+1:]
+	if whole == "" || fraction == "" {
+		return nil, xerrors.Errorf("invalid tez amount %q", s)
+	}
+	if len(fraction) > tezDecimals {
+		return nil, xerrors.Errorf("tez amount %q has more than %d decimal places", s, tezDecimals)
+	}
+	fraction += strings.Repeat("0", tezDecimals-len(fraction))
+	mutez, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok || strings.ContainsAny(whole+fraction, "+-") {
+		return nil, xerrors.Errorf("invalid tez amount %q", s)
+	}
+	return mutez, nil
+}
+
+// Mutez is an amount of mutez. It exists to keep tez and mutez apart: amounts
+// converted from tez are rounded to whole mutez, and amounts print in tez. The zero
+// value is zero mutez.
+type Mutez struct {
+	amount *big.Int
+}
+
+// NewMutez returns an amount of mutez
+func NewMutez(mutez int64) Mutez {
+	return Mutez{amount: big.NewInt(mutez)}
+}
+
+// NewMutezFromBigInt returns an amount of mutez. The amount is copied.
+func NewMutezFromBigInt(mutez *big.Int) Mutez {
+	if mutez == nil {
+		return Mutez{}
+	}
+	return Mutez{amount: new(big.Int).Set(mutez)}
+}
+
+// FromTez converts an amount of tez to mutez, rounding to the nearest mutez. It errors
+// on negative, infinite or NaN amounts.
+func FromTez(tez float64) (Mutez, error) {
+	if math.IsNaN(tez) || math.IsInf(tez, 0) || tez < 0 {
+		return Mutez{}, xerrors.Errorf("invalid tez amount %v", tez)
+	}
+	mutez, err := ParseMutezString(strconv.FormatFloat(tez, 'f', tezDecimals, 64))
+	if err != nil {
+		return Mutez{}, err
+	}
+	return Mutez{amount: mutez}, nil
+}
+
+// BigInt returns the amount in mutez. The returned value is a copy.
+func (m Mutez) BigInt() *big.Int {
+	if m.amount == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(m.amount)
+}
+
+// Add returns m + other
+func (m Mutez) Add(other Mutez) Mutez {
+	return Mutez{amount: new(big.Int).Add(m.BigInt(), other.BigInt())}
+}
+
+// Sub returns m - other
+func (m Mutez) Sub(other Mutez) Mutez {
+	return Mutez{amount: new(big.Int).Sub(m.BigInt(), other.BigInt())}
+}
+
+// ToTez formats the amount in tez with all 6 decimal places, e.g. "1.234567"
+func (m Mutez) ToTez() string {
+	amount := m.BigInt()
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount.Neg(amount)
+	}
+	digits := amount.String()
+	if len(digits) <= tezDecimals {
+		digits = strings.Repeat("0", tezDecimals-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-tezDecimals] + "." + digits[len(digits)-tezDecimals:]
+}
+
+// String implements fmt.Stringer, formatting the amount in tez, e.g. "1.234567 tez"
+func (m Mutez) String() string {
+	return m.ToTez() + " tez"
+}
+
+// MarshalJSON implements json.Marshaler, encoding the amount as a string of mutez
+// as the RPC does, e.g. "1266"
+func (m Mutez) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.BigInt().String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler so that amount and fee fields in
+// configuration can be written either way ParseMutezString accepts: a string or
+// number of mutez, e.g. "1266" or 1266, or a decimal amount of tez, e.g. "0.001".
+func (m *Mutez) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return xerrors.Errorf("invalid amount %s", data)
+		}
+		s = number.String()
+	}
+	mutez, err := ParseMutezString(s)
+	if err != nil {
+		return err
+	}
+	m.amount = mutez
+	return nil
+}
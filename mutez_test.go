@@ -0,0 +1,82 @@
+package tezosprotocol_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMutezString(t *testing.T) {
+	require := require.New(t)
+	for input, expected := range map[string]string{
+		"1266":     "1266",
+		"1.5":      "1500000",
+		"0.001":    "1000",
+		"0.000001": "1",
+	} {
+		mutez, err := tezosprotocol.ParseMutezString(input)
+		require.NoError(err, input)
+		require.Equal(expected, mutez.String(), input)
+	}
+	for _, input := range []string{"abc", "", "-1", "1.", ".5", "1.0000001", "1.-5", "0x10"} {
+		_, err := tezosprotocol.ParseMutezString(input)
+		require.Error(err, input)
+	}
+}
+
+func TestMutez(t *testing.T) {
+	require := require.New(t)
+	amount, err := tezosprotocol.FromTez(1.234567)
+	require.NoError(err)
+	require.Equal("1234567", amount.BigInt().String())
+	require.Equal("1.234567", amount.ToTez())
+	require.Equal("1.234567 tez", amount.String())
+
+	// tez amounts are rounded to the nearest mutez
+	amount, err = tezosprotocol.FromTez(0.1 + 0.2)
+	require.NoError(err)
+	require.Equal("300000", amount.BigInt().String())
+
+	require.Equal("1.000001 tez", tezosprotocol.NewMutez(1000000).Add(tezosprotocol.NewMutez(1)).String())
+	require.Equal("-0.000001 tez", tezosprotocol.NewMutez(0).Sub(tezosprotocol.NewMutez(1)).String())
+	require.Equal("0.000000 tez", tezosprotocol.Mutez{}.String())
+
+	_, err = tezosprotocol.FromTez(-1)
+	require.Error(err)
+
+	transaction := &tezosprotocol.Transaction{}
+	transaction.SetAmount(tezosprotocol.NewMutez(5))
+	transaction.SetFee(tezosprotocol.NewMutez(7))
+	require.Equal(int64(5), transaction.Amount.Int64())
+	require.Equal(int64(7), transaction.Fee.Int64())
+}
+
+func TestMutezJSON(t *testing.T) {
+	require := require.New(t)
+	var config struct {
+		Fee    tezosprotocol.Mutez `json:"fee"`
+		Amount tezosprotocol.Mutez `json:"amount"`
+	}
+	require.NoError(json.Unmarshal([]byte(`{"fee": "1266", "amount": "1.5"}`), &config))
+	require.Equal("1266", config.Fee.BigInt().String())
+	require.Equal("1500000", config.Amount.BigInt().String())
+	require.NoError(json.Unmarshal([]byte(`{"fee": 1266, "amount": 0.001}`), &config))
+	require.Equal("1266", config.Fee.BigInt().String())
+	require.Equal("1000", config.Amount.BigInt().String())
+
+	transaction := &tezosprotocol.Transaction{}
+	transaction.SetFee(config.Fee)
+	transaction.SetAmount(config.Amount)
+	require.Equal(int64(1266), transaction.Fee.Int64())
+	require.Equal(int64(1000), transaction.Amount.Int64())
+
+	encoded, err := json.Marshal(config)
+	require.NoError(err)
+	require.JSONEq(`{"fee": "1266", "amount": "1000"}`, string(encoded))
+
+	for _, input := range []string{`{"fee": "abc"}`, `{"fee": -1}`, `{"fee": true}`} {
+		require.Error(json.Unmarshal([]byte(input), &config), input)
+	}
+}
@@ -3,9 +3,10 @@ package tezosprotocol
 import (
 	"bytes"
 	"encoding"
+	"encoding/binary"
 	"fmt"
+	"math/big"
 
-	"golang.org/x/crypto/blake2b"
 	"golang.org/x/xerrors"
 )
 
@@ -28,6 +29,53 @@ type OperationContents interface {
 	GetTag() ContentsTag
 }
 
+// contentsUnmarshaler is implemented by the operation contents this package can
+// decode. unmarshalBinary decodes the contents at the start of data and returns the
+// number of bytes they occupy, so the next contents can be found without
+// re-marshaling.
+type contentsUnmarshaler interface {
+	OperationContents
+	unmarshalBinary(data []byte) (int, error)
+}
+
+// managerFields points to the fields shared by all manager operation contents
+type managerFields struct {
+	Source       *ContractID
+	Fee          **big.Int
+	Counter      **big.Int
+	GasLimit     **big.Int
+	StorageLimit **big.Int
+}
+
+// getManagerFields returns pointers to the manager fields of content. It returns
+// false if content is not a manager operation.
+func getManagerFields(content OperationContents) (managerFields, bool) {
+	switch c := content.(type) {
+	case *Revelation:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *Transaction:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *Origination:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *Delegation:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *RegisterGlobalConstant:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *SetDepositsLimit:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *IncreasePaidStorage:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *TransferTicket:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *SmartRollupOriginate:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	case *SmartRollupAddMessages:
+		return managerFields{&c.Source, &c.Fee, &c.Counter, &c.GasLimit, &c.StorageLimit}, true
+	default:
+		return managerFields{}, false
+	}
+}
+
 // Operation models a tezos operation with variable length contents.
 type Operation struct {
 	Branch   BranchID
@@ -39,8 +87,16 @@ func (o *Operation) String() string {
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler. It encodes the operation
-// unsigned, in the format suitable for signing and transmission.
+// unsigned, in the format suitable for signing and transmission. This is the
+// p2p form: the branch followed directly by the contents, with neither a length
+// nor a count prefix.
 func (o *Operation) MarshalBinary() ([]byte, error) {
+	return o.MarshalBinaryForProtocol(ProtocolCurrent)
+}
+
+// MarshalBinaryForProtocol encodes the operation like MarshalBinary, laying out its
+// contents as in the given protocol
+func (o *Operation) MarshalBinaryForProtocol(protocol Protocol) ([]byte, error) {
 	buf := bytes.Buffer{}
 
 	branchIDBytes, err := o.Branch.MarshalBinary()
@@ -52,8 +108,11 @@ func (o *Operation) MarshalBinary() ([]byte, error) {
 	if len(o.Contents) == 0 {
 		return nil, xerrors.New("expected non-zero list of contents in an operation")
 	}
+	if err := validateContentsBatch(o.Contents); err != nil {
+		return nil, err
+	}
 	for _, content := range o.Contents {
-		contentBytes, err := content.MarshalBinary()
+		contentBytes, err := protocol.marshalContents(content)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to marshal operation contents: %#v: %w", content, err)
 		}
@@ -62,8 +121,111 @@ func (o *Operation) MarshalBinary() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// TotalFee returns the sum of the fees of the operation's manager contents. Nil
+// fees count as zero.
+func (o *Operation) TotalFee() *big.Int {
+	total := big.NewInt(0)
+	for _, content := range o.Contents {
+		fields, ok := getManagerFields(content)
+		if ok && *fields.Fee != nil {
+			total.Add(total, *fields.Fee)
+		}
+	}
+	return total
+}
+
+// Transactions returns the transactions among the operation's contents, in order
+func (o *Operation) Transactions() []*Transaction {
+	var transactions []*Transaction
+	for _, content := range o.Contents {
+		if transaction, ok := content.(*Transaction); ok {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions
+}
+
+// Delegations returns the delegations among the operation's contents, in order
+func (o *Operation) Delegations() []*Delegation {
+	var delegations []*Delegation
+	for _, content := range o.Contents {
+		if delegation, ok := content.(*Delegation); ok {
+			delegations = append(delegations, delegation)
+		}
+	}
+	return delegations
+}
+
+// Originations returns the originations among the operation's contents, in order
+func (o *Operation) Originations() []*Origination {
+	var originations []*Origination
+	for _, content := range o.Contents {
+		if origination, ok := content.(*Origination); ok {
+			originations = append(originations, origination)
+		}
+	}
+	return originations
+}
+
+// Revelations returns the revelations among the operation's contents, in order
+func (o *Operation) Revelations() []*Revelation {
+	var revelations []*Revelation
+	for _, content := range o.Contents {
+		if revelation, ok := content.(*Revelation); ok {
+			revelations = append(revelations, revelation)
+		}
+	}
+	return revelations
+}
+
+// MarshalBinaryWithLength encodes the operation like MarshalBinary, prefixed
+// with its total length in bytes as a 4-byte big-endian integer. This is the
+// framing used for each operation in an operation list, e.g. when decoding the
+// operations of a block.
+func (o *Operation) MarshalBinaryWithLength() ([]byte, error) {
+	opBytes, err := o.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	lenBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(lenBytes, uint32(len(opBytes)))
+	return append(lenBytes, opBytes...), nil
+}
+
+// UnmarshalOperations decodes a list of operations, each framed as by
+// MarshalBinaryWithLength, such as one validation pass of a block's operations.
+// The operations of a block are a list of such lists, and are hashed to an
+// OperationListListHash.
+func UnmarshalOperations(data []byte) ([]*Operation, error) {
+	var operations []*Operation
+	dataPtr := data
+	for len(dataPtr) > 0 {
+		if len(dataPtr) < 4 {
+			return nil, xerrors.Errorf("truncated length of operation %d", len(operations))
+		}
+		opLen := binary.BigEndian.Uint32(dataPtr[:4])
+		dataPtr = dataPtr[4:]
+		if uint64(opLen) > uint64(len(dataPtr)) {
+			return nil, xerrors.Errorf("operation %d length %d exceeds remaining %d bytes", len(operations), opLen, len(dataPtr))
+		}
+		operation := &Operation{}
+		if err := operation.UnmarshalBinary(dataPtr[:opLen]); err != nil {
+			return nil, xerrors.Errorf("failed to unmarshal operation %d: %w", len(operations), err)
+		}
+		operations = append(operations, operation)
+		dataPtr = dataPtr[opLen:]
+	}
+	return operations, nil
+}
+
 // UnmarshalBinary implements encoding.BinaryUnmarshaler
-func (o *Operation) UnmarshalBinary(data []byte) (err error) {
+func (o *Operation) UnmarshalBinary(data []byte) error {
+	return o.UnmarshalBinaryForProtocol(data, ProtocolCurrent)
+}
+
+// UnmarshalBinaryForProtocol decodes an operation whose contents are laid out as in
+// the given protocol
+func (o *Operation) UnmarshalBinaryForProtocol(data []byte, protocol Protocol) (err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -81,54 +243,145 @@ func (o *Operation) UnmarshalBinary(data []byte) (err error) {
 	}
 	dataPtr = dataPtr[BlockHashLen:]
 	for len(dataPtr) > 0 {
-		tag := ContentsTag(dataPtr[0])
-		var content OperationContents
-		switch tag {
-		case ContentsTagRevelation:
-			content = &Revelation{}
-			err = content.UnmarshalBinary(dataPtr)
-			if err != nil {
-				return xerrors.Errorf("failed to unmarshal revelation: %w", err)
-			}
-		case ContentsTagTransaction:
-			content = &Transaction{}
-			err = content.UnmarshalBinary(dataPtr)
-			if err != nil {
-				return xerrors.Errorf("failed to unmarshal transaction: %w", err)
-			}
-		case ContentsTagOrigination:
-			content = &Origination{}
-			err = content.UnmarshalBinary(dataPtr)
-			if err != nil {
-				return xerrors.Errorf("failed to unmarshal origination: %w", err)
-			}
-		case ContentsTagDelegation:
-			content = &Delegation{}
-			err = content.UnmarshalBinary(dataPtr)
-			if err != nil {
-				return xerrors.Errorf("failed to unmarshal delegation: %w", err)
-			}
-		default:
-			return xerrors.Errorf("unexpected content tag %d", tag)
+		content, bytesRead, err := protocol.unmarshalContents(dataPtr)
+		if err != nil {
+			return xerrors.Errorf("failed to unmarshal contents at byte %d of %d: %w", len(data)-len(dataPtr), len(data), err)
 		}
 		o.Contents = append(o.Contents, content)
-		marshaled, err := content.MarshalBinary()
-		if err != nil {
-			return err
+		dataPtr = dataPtr[bytesRead:]
+	}
+
+	return validateContentsBatch(o.Contents)
+}
+
+// unmarshalContents decodes the contents at the start of data and returns the
+// number of bytes they occupy
+func unmarshalContents(data []byte) (OperationContents, int, error) {
+	var content contentsUnmarshaler
+	var contentName string
+	switch tag := ContentsTag(data[0]); tag {
+	case ContentsTagRevelation:
+		content, contentName = &Revelation{}, "revelation"
+	case ContentsTagTransaction:
+		content, contentName = &Transaction{}, "transaction"
+	case ContentsTagOrigination:
+		content, contentName = &Origination{}, "origination"
+	case ContentsTagDelegation:
+		content, contentName = &Delegation{}, "delegation"
+	case ContentsTagRegisterGlobalConstant:
+		content, contentName = &RegisterGlobalConstant{}, "register global constant"
+	case ContentsTagSetDepositsLimit:
+		content, contentName = &SetDepositsLimit{}, "set deposits limit"
+	case ContentsTagIncreasePaidStorage:
+		content, contentName = &IncreasePaidStorage{}, "increase paid storage"
+	case ContentsTagTransferTicket:
+		content, contentName = &TransferTicket{}, "transfer ticket"
+	case ContentsTagSmartRollupOriginate:
+		content, contentName = &SmartRollupOriginate{}, "smart rollup originate"
+	case ContentsTagSmartRollupAddMessages:
+		content, contentName = &SmartRollupAddMessages{}, "smart rollup add messages"
+	case ContentsTagEndorsement:
+		content, contentName = &Endorsement{}, "endorsement"
+	case ContentsTagConsensusEndorsement:
+		content, contentName = &ConsensusEndorsement{}, "consensus endorsement"
+	case ContentsTagFailingNoop:
+		content, contentName = &FailingNoop{}, "failing noop"
+	case ContentsTagProposals:
+		content, contentName = &Proposals{}, "proposals"
+	case ContentsTagActivateAccount:
+		content, contentName = &AccountActivation{}, "account activation"
+	case ContentsTagSeedNonceRevelation:
+		content, contentName = &SeedNonceRevelation{}, "seed nonce revelation"
+	case ContentsTagDoubleBakingEvidence:
+		content, contentName = &DoubleBakingEvidence{}, "double baking evidence"
+	default:
+		return nil, 0, xerrors.Errorf("unexpected content tag %d", tag)
+	}
+	bytesRead, err := content.unmarshalBinary(data)
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to unmarshal %s: %w", contentName, err)
+	}
+	return content, bytesRead, nil
+}
+
+// validateContentsBatch checks that contents can share an operation. Consensus
+// contents (endorsements) are signed with their own watermark and can't be batched
+// with manager operations.
+func validateContentsBatch(contents []OperationContents) error {
+	hasEndorsement, hasManagerOperation := false, false
+	for _, content := range contents {
+		switch content.(type) {
+		case *Endorsement, *ConsensusEndorsement:
+			hasEndorsement = true
+		}
+		if _, ok := getManagerFields(content); ok {
+			hasManagerOperation = true
 		}
-		dataPtr = dataPtr[len(marshaled):]
 	}
+	if hasEndorsement && hasManagerOperation {
+		return xerrors.New("endorsements cannot be batched with manager operations")
+	}
+	return nil
+}
 
+// Validate checks the operation for mistakes the node would reject at injection:
+// all sourced contents must share the same source, and the counters of manager
+// contents must be set and strictly increasing. Call it before signing to avoid
+// wasting a node round-trip.
+func (o *Operation) Validate() error {
+	if err := validateContentsBatch(o.Contents); err != nil {
+		return err
+	}
+	var source ContractID
+	var lastCounter *big.Int
+	for i, content := range o.Contents {
+		if sourceableContent, ok := content.(interface{ GetSource() ContractID }); ok {
+			contentSource := sourceableContent.GetSource()
+			if source == "" {
+				source = contentSource
+			} else if contentSource != "" && contentSource != source {
+				return xerrors.Errorf("content %d has source %s, but content 0 has source %s", i, contentSource, source)
+			}
+		}
+		fields, ok := getManagerFields(content)
+		if !ok {
+			continue
+		}
+		if *fields.Counter == nil {
+			return xerrors.Errorf("content %d has no counter", i)
+		}
+		if lastCounter != nil && (*fields.Counter).Cmp(lastCounter) <= 0 {
+			return xerrors.Errorf("content %d has counter %s, expected more than %s", i, *fields.Counter, lastCounter)
+		}
+		lastCounter = *fields.Counter
+	}
 	return nil
 }
 
+// SigningPayload returns the bytes to be signed for this operation: the operation
+// watermark followed by the serialized operation. Signers hash the payload with
+// blake2b-256 before signing it. This supports offline signing workflows, where the
+// payload (or its SignatureHash) is exported to a separate signing device.
+func (o *Operation) SigningPayload() ([]byte, error) {
+	operationBytes, err := o.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal operation: %s: %w", o, err)
+	}
+	return append([]byte{byte(OperationWatermark)}, operationBytes...), nil
+}
+
 // SignatureHash returns the hash of the operation to be signed, including watermark
 func (o *Operation) SignatureHash() ([]byte, error) {
 	operationBytes, err := o.MarshalBinary()
 	if err != nil {
 		return nil, xerrors.Errorf("failed to marshal operation: %s: %w", o, err)
 	}
-	bytesWithWatermark := append([]byte{byte(OperationWatermark)}, operationBytes...)
-	sigHash := blake2b.Sum256(bytesWithWatermark)
+	sigHash := SignatureHash(OperationWatermark, operationBytes)
 	return sigHash[:], nil
 }
+
+// AttachSignature pairs the operation with a signature produced elsewhere, such as
+// by an offline signer over SigningPayload or SignatureHash.
+func (o *Operation) AttachSignature(signature Signature) SignedOperation {
+	return SignedOperation{Operation: o, Signature: signature}
+}
@@ -0,0 +1,87 @@
+package tezosprotocol
+
+import (
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+// OperationBuilder assembles an operation of manager contents from a single source,
+// numbering their counters when the operation is built. Errors from the Add methods
+// are reported by Build.
+type OperationBuilder struct {
+	branch   BranchID
+	contents []OperationContents
+	err      error
+}
+
+// NewOperationBuilder returns an empty OperationBuilder
+func NewOperationBuilder() *OperationBuilder {
+	return &OperationBuilder{}
+}
+
+// WithBranch sets the branch of the operation
+func (b *OperationBuilder) WithBranch(branch BranchID) *OperationBuilder {
+	b.branch = branch
+	return b
+}
+
+// AddRevelation appends a revelation to the operation
+func (b *OperationBuilder) AddRevelation(revelation *Revelation) *OperationBuilder {
+	return b.add(revelation)
+}
+
+// AddTransaction appends a transaction to the operation
+func (b *OperationBuilder) AddTransaction(transaction *Transaction) *OperationBuilder {
+	return b.add(transaction)
+}
+
+// AddOrigination appends an origination to the operation
+func (b *OperationBuilder) AddOrigination(origination *Origination) *OperationBuilder {
+	return b.add(origination)
+}
+
+// AddDelegation appends a delegation to the operation
+func (b *OperationBuilder) AddDelegation(delegation *Delegation) *OperationBuilder {
+	return b.add(delegation)
+}
+
+func (b *OperationBuilder) add(content OperationContents) *OperationBuilder {
+	if b.err != nil {
+		return b
+	}
+	fields, _ := getManagerFields(content)
+	if len(b.contents) > 0 {
+		// counters are per source, so they can only be numbered for a single source
+		firstFields, _ := getManagerFields(b.contents[0])
+		if *fields.Source != *firstFields.Source {
+			b.err = xerrors.Errorf("content %d has source %s, expected %s", len(b.contents), *fields.Source, *firstFields.Source)
+			return b
+		}
+	}
+	b.contents = append(b.contents, content)
+	return b
+}
+
+// Build returns the operation, setting the counters of its contents to consecutive
+// values starting at startingCounter, in the order they were added. startingCounter
+// is typically the source's current counter plus one. The contents are modified in
+// place.
+func (b *OperationBuilder) Build(startingCounter *big.Int) (*Operation, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if startingCounter == nil {
+		return nil, xerrors.New("starting counter is required")
+	}
+	if len(b.contents) == 0 {
+		return nil, xerrors.New("expected non-zero list of contents in an operation")
+	}
+	for i, content := range b.contents {
+		fields, _ := getManagerFields(content)
+		*fields.Counter = new(big.Int).Add(startingCounter, big.NewInt(int64(i)))
+	}
+	contents := make([]OperationContents, len(b.contents))
+	copy(contents, b.contents)
+	return &Operation{Branch: b.branch, Contents: contents}, nil
+}
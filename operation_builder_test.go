@@ -0,0 +1,57 @@
+package tezosprotocol_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationBuilder(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	branch := tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB")
+	revelation := tezosprotocol.NewReveal(source, tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"))
+	transaction := tezosprotocol.NewTransfer(source, tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"), 100000000)
+	delegation := tezosprotocol.NewDelegation(source, nil)
+
+	operation, err := tezosprotocol.NewOperationBuilder().
+		WithBranch(branch).
+		AddRevelation(revelation).
+		AddTransaction(transaction).
+		AddDelegation(delegation).
+		Build(big.NewInt(7))
+	require.NoError(err)
+	require.Equal(branch, operation.Branch)
+	require.Equal([]tezosprotocol.OperationContents{revelation, transaction, delegation}, operation.Contents)
+	require.Equal(big.NewInt(7), revelation.Counter)
+	require.Equal(big.NewInt(8), transaction.Counter)
+	require.Equal(big.NewInt(9), delegation.Counter)
+	_, err = operation.MarshalBinary()
+	require.NoError(err)
+}
+
+func TestOperationBuilderErrors(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	otherSource := tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+
+	// mixed sources
+	_, err := tezosprotocol.NewOperationBuilder().
+		AddTransaction(tezosprotocol.NewTransfer(source, otherSource, 1)).
+		AddTransaction(tezosprotocol.NewTransfer(otherSource, source, 1)).
+		Build(big.NewInt(1))
+	require.Error(err)
+	require.Contains(err.Error(), "content 1 has source")
+
+	// no contents
+	_, err = tezosprotocol.NewOperationBuilder().Build(big.NewInt(1))
+	require.Error(err)
+
+	// no starting counter
+	_, err = tezosprotocol.NewOperationBuilder().
+		AddTransaction(tezosprotocol.NewTransfer(source, otherSource, 1)).
+		Build(nil)
+	require.Error(err)
+}
@@ -7,20 +7,13 @@ type OperationHash string
 
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (o OperationHash) MarshalBinary() ([]byte, error) {
-	b58prefix, b58decoded, err := Base58CheckDecode(string(o))
-	if err != nil {
-		return nil, err
-	}
-	if b58prefix != PrefixOperationHash {
-		return nil, xerrors.Errorf("unexpected base58check prefix for operation hash %s", o)
-	}
-	return b58decoded, nil
+	return DecodeBase58Typed(string(o), PrefixOperationHash)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
 func (o *OperationHash) UnmarshalBinary(data []byte) error {
 	if len(data) != OperationHashLen {
-		return xerrors.Errorf("expect operation hash to be %d bytes but received %d", OperationHashLen, len(data))
+		return xerrors.Errorf("expect operation hash to be %d bytes but received %d: %w", OperationHashLen, len(data), ErrUnexpectedLength)
 	}
 	b58checkEncoded, err := Base58CheckEncode(PrefixOperationHash, data)
 	if err != nil {
@@ -0,0 +1,29 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// OperationListListHashLen is the length in bytes of a serialized operation list
+// list hash
+const OperationListListHashLen = 32
+
+// OperationListListHash encodes the hash of the operations of a block, a list of
+// lists of operations, in base58check encoding
+type OperationListListHash string
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (o OperationListListHash) MarshalBinary() ([]byte, error) {
+	return DecodeBase58Typed(string(o), PrefixOperationListListHash)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (o *OperationListListHash) UnmarshalBinary(data []byte) error {
+	if len(data) != OperationListListHashLen {
+		return xerrors.Errorf("expect operation list list hash to be %d bytes but received %d: %w", OperationListListHashLen, len(data), ErrUnexpectedLength)
+	}
+	b58checkEncoded, err := Base58CheckEncode(PrefixOperationListListHash, data)
+	if err != nil {
+		return err
+	}
+	*o = OperationListListHash(b58checkEncoded)
+	return nil
+}
@@ -63,3 +63,266 @@ func TestGetOperationHash(t *testing.T) {
 	require.NoError(err)
 	require.Equal(tezosprotocol.OperationHash("onvk5LwVA1AXnUEvcz17HE2jt2DLkYbqxkbboX53utEJQ56sThr"), operationHash)
 }
+
+func TestDecodeConsensusOperation(t *testing.T) {
+	require := require.New(t)
+	// branch || endorsement(level=450000)
+	encoded, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd0")
+	require.NoError(err)
+	operation := &tezosprotocol.Operation{}
+	require.NoError(operation.UnmarshalBinary(encoded))
+	require.Equal(tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"), operation.Branch)
+	require.Len(operation.Contents, 1)
+	require.Equal(&tezosprotocol.Endorsement{Level: 450000}, operation.Contents[0])
+	reencoded, err := operation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(encoded, reencoded)
+}
+
+func TestEncodeOperationWithLength(t *testing.T) {
+	require := require.New(t)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{&tezosprotocol.Endorsement{Level: 450000}},
+	}
+	encodedBytes, err := operation.MarshalBinary()
+	require.NoError(err)
+	require.Equal("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd0", hex.EncodeToString(encodedBytes))
+
+	encodedBytes, err = operation.MarshalBinaryWithLength()
+	require.NoError(err)
+	require.Equal("00000025e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd0", hex.EncodeToString(encodedBytes))
+}
+
+func TestUnmarshalOperations(t *testing.T) {
+	require := require.New(t)
+	endorsement := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{&tezosprotocol.Endorsement{Level: 450000}},
+	}
+	transfer := tezosprotocol.NewTransfer(tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"), tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"), 1)
+	transfer.Counter = big.NewInt(2)
+	transaction := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{transfer},
+	}
+	endorsementBytes, err := endorsement.MarshalBinaryWithLength()
+	require.NoError(err)
+	transactionBytes, err := transaction.MarshalBinaryWithLength()
+	require.NoError(err)
+	data := append(endorsementBytes, transactionBytes...)
+
+	operations, err := tezosprotocol.UnmarshalOperations(data)
+	require.NoError(err)
+	require.Equal([]*tezosprotocol.Operation{endorsement, transaction}, operations)
+
+	operations, err = tezosprotocol.UnmarshalOperations(nil)
+	require.NoError(err)
+	require.Empty(operations)
+
+	// truncated
+	_, err = tezosprotocol.UnmarshalOperations(data[:len(data)-1])
+	require.Error(err)
+	_, err = tezosprotocol.UnmarshalOperations(append(data, 0))
+	require.Error(err)
+}
+
+func TestOperationTypedContents(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	revelation := tezosprotocol.NewReveal(source, "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	transfer1 := tezosprotocol.NewTransfer(source, "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", 1)
+	transfer2 := tezosprotocol.NewTransfer(source, "tz1ddb9NMYHZi5UzPdzTZMYQQZoMub195zgv", 2)
+	delegation := tezosprotocol.NewDelegation(source, nil)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{revelation, transfer1, delegation, transfer2},
+	}
+	require.Equal([]*tezosprotocol.Revelation{revelation}, operation.Revelations())
+	require.Equal([]*tezosprotocol.Transaction{transfer1, transfer2}, operation.Transactions())
+	require.Equal([]*tezosprotocol.Delegation{delegation}, operation.Delegations())
+	require.Empty(operation.Originations())
+}
+
+func TestOperationTotalFee(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	revelation := tezosprotocol.NewReveal(source, "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	revelation.Fee = big.NewInt(1257)
+	transaction := tezosprotocol.NewTransfer(source, "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", 1)
+	transaction.Fee = big.NewInt(50000)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{revelation, transaction},
+	}
+	require.Equal("51257", operation.TotalFee().String())
+
+	transaction.Fee = nil
+	require.Equal("1257", operation.TotalFee().String())
+}
+
+func TestEndorsementCannotBeBatchedWithManagerOperations(t *testing.T) {
+	require := require.New(t)
+	operation := &tezosprotocol.Operation{
+		Branch: tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{
+			&tezosprotocol.Endorsement{Level: 450000},
+			tezosprotocol.NewReveal("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"),
+		},
+	}
+	_, err := operation.MarshalBinary()
+	require.Error(err)
+	require.Contains(err.Error(), "cannot be batched")
+
+	// decoding a mixed batch is rejected too
+	revelationBytes, err := operation.Contents[1].MarshalBinary()
+	require.NoError(err)
+	encoded := append(fromHex("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd0"), revelationBytes...)
+	require.Error((&tezosprotocol.Operation{}).UnmarshalBinary(encoded))
+}
+
+func TestOperationValidate(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	otherSource := tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+	transfer := func(source tezosprotocol.ContractID, counter int64) *tezosprotocol.Transaction {
+		transaction := tezosprotocol.NewTransfer(source, otherSource, 1)
+		transaction.Counter = big.NewInt(counter)
+		return transaction
+	}
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{transfer(source, 1), transfer(source, 2)},
+	}
+	require.NoError(operation.Validate())
+
+	// mixed sources
+	operation.Contents = []tezosprotocol.OperationContents{transfer(source, 1), transfer(otherSource, 2)}
+	err := operation.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "content 1 has source tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+
+	// repeated counter
+	operation.Contents = []tezosprotocol.OperationContents{transfer(source, 2), transfer(source, 2)}
+	err = operation.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "content 1 has counter 2, expected more than 2")
+
+	// missing counter
+	operation.Contents = []tezosprotocol.OperationContents{tezosprotocol.NewTransfer(source, otherSource, 1)}
+	require.Error(operation.Validate())
+}
+
+func TestDecodeOperationNonCanonicalContents(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	destination := tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq")
+	parameters := tezosprotocol.TransactionParametersValueRawBytes([]byte{0x03, 0x0b})
+	transaction := tezosprotocol.NewTransfer(source, destination, 1)
+	transaction.Counter = big.NewInt(1)
+	transaction.Parameters = &tezosprotocol.TransactionParameters{
+		Entrypoint: tezosprotocol.EntrypointDo,
+		Value:      &parameters,
+	}
+	revelation := tezosprotocol.NewReveal(source, tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"))
+	revelation.Counter = big.NewInt(2)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{transaction, revelation},
+	}
+	encoded, err := operation.MarshalBinary()
+	require.NoError(err)
+
+	// a transaction with parameters can be followed by other contents
+	decoded := tezosprotocol.Operation{}
+	require.NoError(decoded.UnmarshalBinary(encoded))
+	require.Len(decoded.Contents, 2)
+
+	// encode the transaction's zero fee as the two byte zarith 0x80 0x00. It decodes
+	// to the same value but re-encodes shorter, which must not desync decoding.
+	feeOffset := tezosprotocol.BlockHashLen + 1 + tezosprotocol.TaggedPubKeyHashLen
+	require.Equal(byte(0x00), encoded[feeOffset])
+	nonCanonical := append(append(append([]byte{}, encoded[:feeOffset]...), 0x80, 0x00), encoded[feeOffset+1:]...)
+	decoded = tezosprotocol.Operation{}
+	require.NoError(decoded.UnmarshalBinary(nonCanonical))
+	require.Len(decoded.Contents, 2)
+	require.Equal(int64(0), decoded.Contents[0].(*tezosprotocol.Transaction).Fee.Int64())
+	require.IsType(&tezosprotocol.Revelation{}, decoded.Contents[1])
+}
+
+func TestOperationAthensEncoding(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	destination := tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+	revelation := tezosprotocol.NewReveal(source, tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"))
+	revelation.Fee = big.NewInt(1266)
+	revelation.Counter = big.NewInt(1)
+	revelation.GasLimit = big.NewInt(10100)
+	revelation.StorageLimit = big.NewInt(0)
+	// originated accounts could be sources in protocol 004
+	transaction := tezosprotocol.NewTransfer("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq", destination, 1000000)
+	transaction.Counter = big.NewInt(2)
+	transaction.GasLimit = big.NewInt(10100)
+	transaction.StorageLimit = big.NewInt(277)
+	unit := tezosprotocol.TransactionParametersValueRawBytes{0x03, 0x0b}
+	transaction.Parameters = &tezosprotocol.TransactionParameters{Entrypoint: tezosprotocol.EntrypointDefault, Value: &unit}
+	delegation := tezosprotocol.NewDelegation(source, &destination)
+	delegation.Counter = big.NewInt(3)
+	delegation.GasLimit = big.NewInt(10100)
+	delegation.StorageLimit = big.NewInt(0)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{revelation, transaction, delegation},
+	}
+	athens, err := operation.MarshalBinaryForProtocol(tezosprotocol.ProtocolAthens)
+	require.NoError(err)
+	expected := "e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f" +
+		// reveal: $contract_id source, fee, counter, gas and storage limits, public key
+		"07" + "000002298c03ed7d454a101eb7022bc95f7e5f41ac78" + "f209" + "01" + "f44e" + "00" +
+		"004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f" +
+		// transaction: amount, destination and parameters without an entrypoint
+		"08" + "015ab81204ccd229281b9c462edaf0a43e78075f4600" + "00" + "02" + "f44e" + "9502" +
+		"c0843d" + "0000e7670f32038107a59a2b9cfefae36ea21f5aa63c" + "ff" + "00000002030b" +
+		// delegation
+		"0a" + "000002298c03ed7d454a101eb7022bc95f7e5f41ac78" + "00" + "03" + "f44e" + "00" +
+		"ff" + "00e7670f32038107a59a2b9cfefae36ea21f5aa63c"
+	require.Equal(expected, hex.EncodeToString(athens))
+
+	decoded := tezosprotocol.Operation{}
+	require.NoError(decoded.UnmarshalBinaryForProtocol(athens, tezosprotocol.ProtocolAthens))
+	require.Equal(operation, &decoded)
+
+	// the current encoding differs by more than the tags
+	revelationOnly := &tezosprotocol.Operation{Branch: operation.Branch, Contents: []tezosprotocol.OperationContents{revelation}}
+	current, err := revelationOnly.MarshalBinary()
+	require.NoError(err)
+	require.Error(decoded.UnmarshalBinaryForProtocol(current, tezosprotocol.ProtocolAthens))
+	require.Error(decoded.UnmarshalBinary(athens))
+
+	// consensus and governance contents kept their layout under other tags
+	endorsement := &tezosprotocol.Operation{
+		Branch:   operation.Branch,
+		Contents: []tezosprotocol.OperationContents{&tezosprotocol.Endorsement{Level: 5}},
+	}
+	athens, err = endorsement.MarshalBinaryForProtocol(tezosprotocol.ProtocolAthens)
+	require.NoError(err)
+	require.Equal("0000000005", hex.EncodeToString(athens[tezosprotocol.BlockHashLen:]))
+	require.NoError(decoded.UnmarshalBinaryForProtocol(athens, tezosprotocol.ProtocolAthens))
+	require.Equal(endorsement, &decoded)
+	require.Equal(byte(0), athens[tezosprotocol.BlockHashLen], "input must not be modified")
+
+	// entrypoints didn't exist yet
+	transaction.Parameters.Entrypoint = tezosprotocol.EntrypointDo
+	_, err = operation.MarshalBinaryForProtocol(tezosprotocol.ProtocolAthens)
+	require.Error(err)
+
+	// contents introduced later have no athens tag, and athens originations aren't modeled
+	for _, content := range []tezosprotocol.OperationContents{
+		&tezosprotocol.FailingNoop{Arbitrary: []byte("hi")},
+		tezosprotocol.NewOrigination(source, 0, tezosprotocol.ContractScript{Code: []byte{0x02}, Storage: []byte{0x03}}),
+	} {
+		operation.Contents = []tezosprotocol.OperationContents{content}
+		_, err = operation.MarshalBinaryForProtocol(tezosprotocol.ProtocolAthens)
+		require.Error(err)
+	}
+}
@@ -21,6 +21,21 @@ type Origination struct {
 	Script       ContractScript
 }
 
+// NewOrigination returns an origination of script by source with an initial
+// balance of balanceMutez and no delegate. The fee is zero and the gas and storage
+// limits are the per-operation maximums; all three should be lowered after
+// estimation. The counter is left unset.
+func NewOrigination(source ContractID, balanceMutez int64, script ContractScript) *Origination {
+	return &Origination{
+		Source:       source,
+		Fee:          big.NewInt(0),
+		GasLimit:     big.NewInt(HardGasLimitPerOperation),
+		StorageLimit: big.NewInt(HardStorageLimitPerOperation),
+		Balance:      big.NewInt(balanceMutez),
+		Script:       script,
+	}
+}
+
 func (o *Origination) String() string {
 	return fmt.Sprintf("%#v", o)
 }
@@ -35,6 +50,18 @@ func (o *Origination) GetSource() ContractID {
 	return o.Source
 }
 
+// BalanceMutez returns the balance as an int64 number of mutez. It errors if the balance
+// does not fit in an int64.
+func (o *Origination) BalanceMutez() (int64, error) {
+	return mutezToInt64("balance", o.Balance)
+}
+
+// FeeMutez returns the fee as an int64 number of mutez. It errors if the fee
+// does not fit in an int64.
+func (o *Origination) FeeMutez() (int64, error) {
+	return mutezToInt64("fee", o.Fee)
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler
 func (o *Origination) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -107,7 +134,14 @@ func (o *Origination) MarshalBinary() ([]byte, error) {
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler
-func (o *Origination) UnmarshalBinary(data []byte) (err error) {
+func (o *Origination) UnmarshalBinary(data []byte) error {
+	_, err := o.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the origination at the start of data and returns the number
+// of bytes it occupies
+func (o *Origination) unmarshalBinary(data []byte) (_ int, err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -122,14 +156,14 @@ func (o *Origination) UnmarshalBinary(data []byte) (err error) {
 	// tag
 	tag := ContentsTag(dataPtr[0])
 	if tag != ContentsTagOrigination {
-		return xerrors.Errorf("invalid tag for origination. Expected %d, saw %d", ContentsTagOrigination, tag)
+		return 0, xerrors.Errorf("invalid tag for origination. Expected %d, saw %d", ContentsTagOrigination, tag)
 	}
 	dataPtr = dataPtr[1:]
 
 	// source
 	err = o.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal source: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
 	}
 	dataPtr = dataPtr[TaggedPubKeyHashLen:]
 
@@ -137,42 +171,42 @@ func (o *Origination) UnmarshalBinary(data []byte) (err error) {
 	var bytesRead int
 	o.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal fee: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// counter
 	o.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal counter: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// gas limit
 	o.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// storage limit
 	o.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// balance
 	o.Balance, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal balance: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal balance: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// delegate
 	hasDelegate, err := deserializeBoolean(dataPtr[0])
 	if err != nil {
-		return xerrors.Errorf("failed to deserialize presence of field \"delegate\": %w", err)
+		return 0, xerrors.Errorf("failed to deserialize presence of field \"delegate\": %w", err)
 	}
 	dataPtr = dataPtr[1:]
 	if hasDelegate {
@@ -180,17 +214,18 @@ func (o *Origination) UnmarshalBinary(data []byte) (err error) {
 		var delegate ContractID
 		err = delegate.UnmarshalBinary(taggedPubKeyHash)
 		if err != nil {
-			return xerrors.Errorf("failed to deserialize delegate: %w", err)
+			return 0, xerrors.Errorf("failed to deserialize delegate: %w", err)
 		}
 		o.Delegate = &delegate
 		dataPtr = dataPtr[TaggedPubKeyHashLen:]
 	}
 
-	// script
-	err = o.Script.UnmarshalBinary(dataPtr)
+	// script. Contents may follow it in an operation.
+	bytesRead, err = o.Script.unmarshalBinary(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to deserialize script: %w", err)
+		return 0, xerrors.Errorf("failed to deserialize script: %w", err)
 	}
+	dataPtr = dataPtr[bytesRead:]
 
-	return nil
+	return len(data) - len(dataPtr), nil
 }
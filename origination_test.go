@@ -66,3 +66,16 @@ func TestDecodeOrigination(t *testing.T) {
 	require.Equal(primUnit, origination.Script.Code)
 	require.Equal(primUnit, origination.Script.Storage)
 }
+
+func TestNewOrigination(t *testing.T) {
+	require := require.New(t)
+	script := tezosprotocol.ContractScript{Code: []byte{0x02, 0x00, 0x00, 0x00, 0x00}, Storage: []byte{0x03, 0x0b}}
+	origination := tezosprotocol.NewOrigination("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 1000000, script)
+	require.Equal(script, origination.Script)
+	require.Equal("1000000", origination.Balance.String())
+	require.Nil(origination.Delegate)
+	require.Equal("0", origination.Fee.String())
+	require.Nil(origination.Counter)
+	require.Equal(tezosprotocol.HardGasLimitPerOperation, origination.GasLimit.Int64())
+	require.Equal(tezosprotocol.HardStorageLimitPerOperation, origination.StorageLimit.Int64())
+}
@@ -0,0 +1,130 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// Proposals models the tezos proposals operation type, with which a delegate
+// submits or upvotes protocol amendments during a proposal period
+type Proposals struct {
+	Source    ContractID
+	Period    int32
+	Proposals []ProtocolHash
+}
+
+func (p *Proposals) String() string {
+	return fmt.Sprintf("%#v", p)
+}
+
+// GetTag implements OperationContents
+func (p *Proposals) GetTag() ContentsTag {
+	return ContentsTagProposals
+}
+
+// GetSource returns the operation's source
+func (p *Proposals) GetSource() ContractID {
+	return p.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (p *Proposals) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(p.GetTag()))
+
+	// source
+	sourceBytes, err := p.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// period
+	err = binary.Write(&buf, binary.BigEndian, p.Period)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write period: %w", err)
+	}
+
+	// proposals
+	err = binary.Write(&buf, binary.BigEndian, uint32(len(p.Proposals)*ProtocolHashLen))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write proposals length: %w", err)
+	}
+	for _, proposal := range p.Proposals {
+		proposalBytes, err := proposal.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write proposal: %w", err)
+		}
+		buf.Write(proposalBytes)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (p *Proposals) UnmarshalBinary(data []byte) error {
+	_, err := p.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the proposals at the start of data and returns the number
+// of bytes it occupies
+func (p *Proposals) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagProposals {
+		return 0, xerrors.Errorf("invalid tag for proposals. Expected %d, saw %d", ContentsTagProposals, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = p.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// period
+	p.Period, err = readInt32(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal period: %w", err)
+	}
+	dataPtr = dataPtr[4:]
+
+	// proposals
+	proposalsLen := binary.BigEndian.Uint32(dataPtr)
+	dataPtr = dataPtr[4:]
+	if proposalsLen%ProtocolHashLen != 0 {
+		return 0, xerrors.Errorf("proposals length %d is not a multiple of %d", proposalsLen, ProtocolHashLen)
+	}
+	proposalsBytes := dataPtr[:proposalsLen]
+	dataPtr = dataPtr[proposalsLen:]
+	p.Proposals = nil
+	for len(proposalsBytes) > 0 {
+		var proposal ProtocolHash
+		err = proposal.UnmarshalBinary(proposalsBytes[:ProtocolHashLen])
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal proposal: %w", err)
+		}
+		p.Proposals = append(p.Proposals, proposal)
+		proposalsBytes = proposalsBytes[ProtocolHashLen:]
+	}
+
+	return len(data) - len(dataPtr), nil
+}
@@ -0,0 +1,49 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const proposalsHex = "0500" + "02298c03ed7d454a101eb7022bc95f7e5f41ac78" + "0000000a" + "00000040" +
+	"3d0b4bacb5c3e152a167da26fefc266bd3a0e14fc4e41e6c53623bf482833da2" +
+	"3e5e3a606afab74a59ca09e333633e2770b6492c5e594455b71e9a2f0ea92afb"
+
+func TestEncodeProposals(t *testing.T) {
+	require := require.New(t)
+	proposals := &tezosprotocol.Proposals{
+		Source: tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Period: 10,
+		Proposals: []tezosprotocol.ProtocolHash{
+			"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS",
+			"PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb",
+		},
+	}
+	encodedBytes, err := proposals.MarshalBinary()
+	require.NoError(err)
+	require.Equal(proposalsHex, hex.EncodeToString(encodedBytes))
+}
+
+func TestDecodeProposals(t *testing.T) {
+	require := require.New(t)
+	encoded, err := hex.DecodeString(proposalsHex)
+	require.NoError(err)
+	proposals := tezosprotocol.Proposals{}
+	require.NoError(proposals.UnmarshalBinary(encoded))
+	require.Equal(tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"), proposals.Source)
+	require.Equal(int32(10), proposals.Period)
+	require.Equal([]tezosprotocol.ProtocolHash{
+		"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS",
+		"PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb",
+	}, proposals.Proposals)
+
+	// inside an operation
+	branch, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f")
+	require.NoError(err)
+	operation := tezosprotocol.Operation{}
+	require.NoError(operation.UnmarshalBinary(append(branch, encoded...)))
+	require.Equal(&proposals, operation.Contents[0])
+}
@@ -0,0 +1,58 @@
+package tezosprotocol
+
+import (
+	"golang.org/x/xerrors"
+)
+
+// Protocol selects the encoding of operation contents. Protocols have renumbered
+// content tags and changed the layout of contents, so replaying a historical
+// operation needs the encoding of the protocol it was made for. The zero value is
+// ProtocolCurrent.
+type Protocol int
+
+const (
+	// ProtocolCurrent uses the encoding of the contents in this package
+	ProtocolCurrent Protocol = iota
+	// ProtocolAthens uses the encoding of protocol 004 and earlier, in which manager
+	// operations were numbered from 7 rather than from 107, had a $contract_id source
+	// and called no entrypoints. Originations, whose protocol 004 layout has fields
+	// Origination does not model, and contents introduced in later protocols can't be
+	// encoded.
+	ProtocolAthens
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case ProtocolCurrent:
+		return "current"
+	case ProtocolAthens:
+		return "athens"
+	default:
+		return "<invalid protocol>"
+	}
+}
+
+// marshalContents encodes content in the layout of this protocol
+func (p Protocol) marshalContents(content OperationContents) ([]byte, error) {
+	switch p {
+	case ProtocolCurrent:
+		return content.MarshalBinary()
+	case ProtocolAthens:
+		return marshalAthensContents(content)
+	default:
+		return nil, xerrors.Errorf("unsupported protocol %d", p)
+	}
+}
+
+// unmarshalContents decodes the contents laid out as in this protocol at the start
+// of data and returns the number of bytes they occupy
+func (p Protocol) unmarshalContents(data []byte) (OperationContents, int, error) {
+	switch p {
+	case ProtocolCurrent:
+		return unmarshalContents(data)
+	case ProtocolAthens:
+		return unmarshalAthensContents(data)
+	default:
+		return nil, 0, xerrors.Errorf("unsupported protocol %d", p)
+	}
+}
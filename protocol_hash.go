@@ -0,0 +1,27 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// ProtocolHashLen is the length in bytes of a serialized protocol hash
+const ProtocolHashLen = 32
+
+// ProtocolHash encodes a protocol hash in base58check encoding
+type ProtocolHash string
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (p ProtocolHash) MarshalBinary() ([]byte, error) {
+	return DecodeBase58Typed(string(p), PrefixProtocolHash)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *ProtocolHash) UnmarshalBinary(data []byte) error {
+	if len(data) != ProtocolHashLen {
+		return xerrors.Errorf("expect protocol hash to be %d bytes but received %d: %w", ProtocolHashLen, len(data), ErrUnexpectedLength)
+	}
+	b58checkEncoded, err := Base58CheckEncode(PrefixProtocolHash, data)
+	if err != nil {
+		return err
+	}
+	*p = ProtocolHash(b58checkEncoded)
+	return nil
+}
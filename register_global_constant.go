@@ -0,0 +1,161 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// RegisterGlobalConstant models the tezos register_global_constant operation type,
+// which registers a Micheline value that contracts can then refer to by its hash.
+// It relies on the Micheline encoder to serialize Value.
+type RegisterGlobalConstant struct {
+	Source       ContractID
+	Fee          *big.Int
+	Counter      *big.Int
+	GasLimit     *big.Int
+	StorageLimit *big.Int
+	Value        MichelineNode
+}
+
+func (r *RegisterGlobalConstant) String() string {
+	return fmt.Sprintf("%#v", r)
+}
+
+// GetTag implements OperationContents
+func (r *RegisterGlobalConstant) GetTag() ContentsTag {
+	return ContentsTagRegisterGlobalConstant
+}
+
+// GetSource returns the operation's source
+func (r *RegisterGlobalConstant) GetSource() ContractID {
+	return r.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (r *RegisterGlobalConstant) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(r.GetTag()))
+
+	// source
+	sourceBytes, err := r.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// fee
+	fee, err := zarith.Encode(r.Fee)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Fee: %w", err)
+	}
+	buf.Write(fee)
+
+	// counter
+	counter, err := zarith.Encode(r.Counter)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Counter: %w", err)
+	}
+	buf.Write(counter)
+
+	// gas limit
+	gasLimit, err := zarith.Encode(r.GasLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write GasLimit: %w", err)
+	}
+	buf.Write(gasLimit)
+
+	// storage limit
+	storageLimit, err := zarith.Encode(r.StorageLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write StorageLimit: %w", err)
+	}
+	buf.Write(storageLimit)
+
+	// value
+	valueBytes, err := marshalLengthPrefixedMicheline(r.Value)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write value: %w", err)
+	}
+	buf.Write(valueBytes)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *RegisterGlobalConstant) UnmarshalBinary(data []byte) error {
+	_, err := r.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the register global constant at the start of data and returns the number
+// of bytes it occupies
+func (r *RegisterGlobalConstant) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagRegisterGlobalConstant {
+		return 0, xerrors.Errorf("invalid tag for register global constant. Expected %d, saw %d", ContentsTagRegisterGlobalConstant, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = r.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// fee
+	var bytesRead int
+	r.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// counter
+	r.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// gas limit
+	r.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// storage limit
+	r.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// value
+	r.Value, bytesRead, err = unmarshalLengthPrefixedMicheline(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal value: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	return len(data) - len(dataPtr), nil
+}
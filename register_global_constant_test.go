@@ -0,0 +1,38 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterGlobalConstant(t *testing.T) {
+	require := require.New(t)
+	registration := &tezosprotocol.RegisterGlobalConstant{
+		Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Fee:          big.NewInt(1000),
+		Counter:      big.NewInt(2),
+		GasLimit:     big.NewInt(1500),
+		StorageLimit: big.NewInt(100),
+		Value:        michelineInt(42),
+	}
+	encodedBytes, err := registration.MarshalBinary()
+	require.NoError(err)
+	expected := "6f0002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b64" + "00000002002a"
+	require.Equal(expected, hex.EncodeToString(encodedBytes))
+
+	decoded := &tezosprotocol.RegisterGlobalConstant{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(registration, decoded)
+
+	// the declared length must match the Micheline value
+	malformed, err := hex.DecodeString("6f0002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b64" + "00000003002a00")
+	require.NoError(err)
+	require.Error(decoded.UnmarshalBinary(malformed))
+
+	_, err = (&tezosprotocol.RegisterGlobalConstant{Source: registration.Source}).MarshalBinary()
+	require.Error(err)
+}
@@ -19,6 +19,18 @@ type Revelation struct {
 	PublicKey    PublicKey
 }
 
+// NewReveal returns a revelation of publicKey for source. The fee is zero and
+// should be filled in after estimation, and the counter is left unset.
+func NewReveal(source ContractID, publicKey PublicKey) *Revelation {
+	return &Revelation{
+		Source:       source,
+		Fee:          big.NewInt(0),
+		GasLimit:     big.NewInt(RevelationGasLimit),
+		StorageLimit: big.NewInt(RevelationStorageLimitBytes),
+		PublicKey:    publicKey,
+	}
+}
+
 func (r *Revelation) String() string {
 	return fmt.Sprintf("%#v", r)
 }
@@ -33,6 +45,41 @@ func (r *Revelation) GetSource() ContractID {
 	return r.Source
 }
 
+// FeeMutez returns the fee as an int64 number of mutez. It errors if the fee
+// does not fit in an int64.
+func (r *Revelation) FeeMutez() (int64, error) {
+	return mutezToInt64("fee", r.Fee)
+}
+
+// NewRevealForKey returns a revelation of the public key of privateKey for source,
+// with the same defaults as NewReveal. It errors if the public key does not hash to
+// source.
+func NewRevealForKey(source ContractID, privateKey PrivateKey) (*Revelation, error) {
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to derive public key: %w", err)
+	}
+	revelation := NewReveal(source, publicKey)
+	if err := revelation.Validate(); err != nil {
+		return nil, err
+	}
+	return revelation, nil
+}
+
+// Validate checks that the revealed public key hashes to the revelation's source.
+// The node rejects revelations where they differ, but MarshalBinary does not check
+// it.
+func (r *Revelation) Validate() error {
+	derivedSource, err := NewContractIDFromPublicKey(r.PublicKey)
+	if err != nil {
+		return xerrors.Errorf("failed to derive address from public key: %w", err)
+	}
+	if derivedSource != r.Source {
+		return xerrors.Errorf("public key %s belongs to %s, not to source %s", r.PublicKey, derivedSource, r.Source)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler
 func (r *Revelation) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -85,8 +132,22 @@ func (r *Revelation) MarshalBinary() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler
-func (r *Revelation) UnmarshalBinary(data []byte) (err error) {
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It errors if data contains
+// trailing bytes after the revelation.
+func (r *Revelation) UnmarshalBinary(data []byte) error {
+	bytesRead, err := r.unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	if bytesRead != len(data) {
+		return xerrors.Errorf("unexpected trailing bytes after revelation: consumed %d of %d bytes", bytesRead, len(data))
+	}
+	return nil
+}
+
+// unmarshalBinary decodes the revelation at the start of data and returns the number
+// of bytes it occupies
+func (r *Revelation) unmarshalBinary(data []byte) (_ int, err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -101,14 +162,14 @@ func (r *Revelation) UnmarshalBinary(data []byte) (err error) {
 	// tag
 	tag := ContentsTag(dataPtr[0])
 	if tag != ContentsTagRevelation {
-		return xerrors.Errorf("invalid tag for revelation. Expected %d, saw %d", ContentsTagRevelation, tag)
+		return 0, xerrors.Errorf("invalid tag for revelation. Expected %d, saw %d", ContentsTagRevelation, tag)
 	}
 	dataPtr = dataPtr[1:]
 
 	// source
 	err = r.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal source: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
 	}
 	dataPtr = dataPtr[TaggedPubKeyHashLen:]
 
@@ -116,36 +177,37 @@ func (r *Revelation) UnmarshalBinary(data []byte) (err error) {
 	var bytesRead int
 	r.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal fee: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// counter
 	r.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal counter: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// gas limit
 	r.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// storage limit
 	r.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// public key
-	err = r.PublicKey.UnmarshalBinary(dataPtr)
+	bytesRead, err = r.PublicKey.unmarshalBinary(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal public key: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal public key: %w", err)
 	}
+	dataPtr = dataPtr[bytesRead:]
 
-	return nil
+	return len(data) - len(dataPtr), nil
 }
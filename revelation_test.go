@@ -1,12 +1,15 @@
 package tezosprotocol_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"math/big"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/anchorageoss/tezosprotocol/v3/testutil"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
 )
 
 func TestEncodeRevelation(t *testing.T) {
@@ -24,6 +27,7 @@ func TestEncodeRevelation(t *testing.T) {
 	encoded := hex.EncodeToString(encodedBytes)
 	expected := "6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f"
 	require.Equal(expected, encoded)
+	testutil.AssertForgeMatchesGolden(t, "revelation", revelation)
 }
 
 func TestDecodeRevelation(t *testing.T) {
@@ -39,3 +43,54 @@ func TestDecodeRevelation(t *testing.T) {
 	require.Equal("0", revelation.StorageLimit.String())
 	require.Equal(tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"), revelation.PublicKey)
 }
+
+func TestDecodeRevelationTrailingBytes(t *testing.T) {
+	require := require.New(t)
+	encoded, err := hex.DecodeString("6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f" + "0001")
+	require.NoError(err)
+	revelation := tezosprotocol.Revelation{}
+	err = revelation.UnmarshalBinary(encoded)
+	require.Error(err)
+	require.Contains(err.Error(), "consumed 61 of 63 bytes")
+}
+
+func TestNewReveal(t *testing.T) {
+	require := require.New(t)
+	publicKey := tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	revelation := tezosprotocol.NewReveal("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", publicKey)
+	require.Equal(publicKey, revelation.PublicKey)
+	require.Equal("0", revelation.Fee.String())
+	require.Nil(revelation.Counter)
+	require.Equal(tezosprotocol.RevelationGasLimit, revelation.GasLimit.Int64())
+	require.Equal(tezosprotocol.RevelationStorageLimitBytes, revelation.StorageLimit.Int64())
+}
+
+func TestValidateRevelation(t *testing.T) {
+	require := require.New(t)
+	publicKey := tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	require.NoError(tezosprotocol.NewReveal("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", publicKey).Validate())
+
+	err := tezosprotocol.NewReveal("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", publicKey).Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "belongs to tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+}
+
+func TestNewRevealForKey(t *testing.T) {
+	require := require.New(t)
+	cryptoPrivateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	privateKey, err := tezosprotocol.NewPrivateKeyFromCryptoPrivateKey(cryptoPrivateKey)
+	require.NoError(err)
+	expectedPublicKey, err := tezosprotocol.NewPublicKeyFromCryptoPublicKey(cryptoPrivateKey.Public())
+	require.NoError(err)
+	source, err := tezosprotocol.NewContractIDFromPublicKey(expectedPublicKey)
+	require.NoError(err)
+
+	revelation, err := tezosprotocol.NewRevealForKey(source, privateKey)
+	require.NoError(err)
+	require.Equal(source, revelation.Source)
+	require.Equal(expectedPublicKey, revelation.PublicKey)
+	require.Equal(tezosprotocol.RevelationGasLimit, revelation.GasLimit.Int64())
+
+	_, err = tezosprotocol.NewRevealForKey("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", privateKey)
+	require.Error(err)
+}
@@ -0,0 +1,87 @@
+package tezosprotocol
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+// signECDSADeterministic signs hash with key using the deterministic nonce of
+// RFC 6979 with HMAC-SHA256, so that a given key and message always produce the
+// same signature. s is normalized to the lower half of the curve order, since
+// tezos rejects the malleable high-S form of secp256k1 signatures.
+// Reference: https://tools.ietf.org/html/rfc6979#section-3.2
+func signECDSADeterministic(key *ecdsa.PrivateKey, hash []byte) (*big.Int, *big.Int, error) {
+	curve := key.Curve
+	n := curve.Params().N
+	orderLen := (n.BitLen() + 7) / 8
+	e := hashToInt(hash, n)
+
+	// step b-g: seed the HMAC_DRBG with the private key and hash
+	x := key.D.FillBytes(make([]byte, orderLen))
+	h := new(big.Int).Mod(e, n).FillBytes(make([]byte, orderLen))
+	v := bytesOf(0x01, sha256.Size)
+	k := bytesOf(0x00, sha256.Size)
+	k = hmacSHA256(k, v, []byte{0x00}, x, h)
+	v = hmacSHA256(k, v)
+	k = hmacSHA256(k, v, []byte{0x01}, x, h)
+	v = hmacSHA256(k, v)
+
+	// step h: generate nonces until one gives a valid signature
+	for i := 0; i < 100; i++ {
+		var t []byte
+		for len(t) < orderLen {
+			v = hmacSHA256(k, v)
+			t = append(t, v...)
+		}
+		nonce := hashToInt(t, n)
+		if nonce.Sign() > 0 && nonce.Cmp(n) < 0 {
+			rX, _ := curve.ScalarBaseMult(nonce.FillBytes(make([]byte, orderLen)))
+			r := rX.Mod(rX, n)
+			if r.Sign() != 0 {
+				s := new(big.Int).Mul(r, key.D)
+				s.Add(s, e)
+				s.Mul(s, new(big.Int).ModInverse(nonce, n))
+				s.Mod(s, n)
+				if s.Sign() != 0 {
+					if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+						s.Sub(n, s)
+					}
+					return r, s, nil
+				}
+			}
+		}
+		k = hmacSHA256(k, v, []byte{0x00})
+		v = hmacSHA256(k, v)
+	}
+	return nil, nil, xerrors.New("failed to generate a signature nonce")
+}
+
+// hashToInt converts a hash to an integer, keeping its leftmost bits if it is
+// longer than the curve order (bits2int in RFC 6979)
+func hashToInt(hash []byte, n *big.Int) *big.Int {
+	ret := new(big.Int).SetBytes(hash)
+	if excess := len(hash)*8 - n.BitLen(); excess > 0 {
+		ret.Rsh(ret, uint(excess))
+	}
+	return ret
+}
+
+func hmacSHA256(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, d := range data {
+		mac.Write(d) //nolint:errcheck
+	}
+	return mac.Sum(nil)
+}
+
+func bytesOf(b byte, n int) []byte {
+	ret := make([]byte, n)
+	for i := range ret {
+		ret[i] = b
+	}
+	return ret
+}
@@ -0,0 +1,307 @@
+package tezosprotocol_test
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripIterations is the number of random instances generated per content type
+const roundTripIterations = 200
+
+// contentGenerators builds a random but valid instance of each operation content
+// type. New content types should be registered here so they are covered by
+// TestContentsRoundTrip.
+var contentGenerators = map[tezosprotocol.ContentsTag]func(r *rand.Rand) tezosprotocol.OperationContents{
+	tezosprotocol.ContentsTagRevelation: func(r *rand.Rand) tezosprotocol.OperationContents {
+		return &tezosprotocol.Revelation{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+			PublicKey:    randomPublicKey(r),
+		}
+	},
+	tezosprotocol.ContentsTagTransaction: func(r *rand.Rand) tezosprotocol.OperationContents {
+		transaction := &tezosprotocol.Transaction{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+			Amount:       randomZarith(r),
+			Destination:  randomContractID(r),
+		}
+		if r.Intn(2) == 0 {
+			value := tezosprotocol.TransactionParametersValueRawBytes(randomBytes(r, r.Intn(64)))
+			transaction.Parameters = &tezosprotocol.TransactionParameters{
+				Entrypoint: tezosprotocol.EntrypointDo,
+				Value:      &value,
+			}
+		}
+		return transaction
+	},
+	tezosprotocol.ContentsTagOrigination: func(r *rand.Rand) tezosprotocol.OperationContents {
+		origination := &tezosprotocol.Origination{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+			Balance:      randomZarith(r),
+			// code and storage are Micheline expressions, which are never empty
+			Script: tezosprotocol.ContractScript{
+				Code:    randomBytes(r, 1+r.Intn(64)),
+				Storage: randomBytes(r, 1+r.Intn(64)),
+			},
+		}
+		if r.Intn(2) == 0 {
+			delegate := randomImplicitContractID(r)
+			origination.Delegate = &delegate
+		}
+		return origination
+	},
+	tezosprotocol.ContentsTagDelegation: func(r *rand.Rand) tezosprotocol.OperationContents {
+		delegation := &tezosprotocol.Delegation{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+		}
+		if r.Intn(2) == 0 {
+			delegate := randomImplicitContractID(r)
+			delegation.Delegate = &delegate
+		}
+		return delegation
+	},
+	tezosprotocol.ContentsTagRegisterGlobalConstant: func(r *rand.Rand) tezosprotocol.OperationContents {
+		value := tezosprotocol.MichelineBytes(randomBytes(r, r.Intn(64)))
+		return &tezosprotocol.RegisterGlobalConstant{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+			Value:        &value,
+		}
+	},
+	tezosprotocol.ContentsTagSetDepositsLimit: func(r *rand.Rand) tezosprotocol.OperationContents {
+		setDepositsLimit := &tezosprotocol.SetDepositsLimit{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+		}
+		if r.Intn(2) == 0 {
+			setDepositsLimit.Limit = randomZarith(r)
+		}
+		return setDepositsLimit
+	},
+	tezosprotocol.ContentsTagIncreasePaidStorage: func(r *rand.Rand) tezosprotocol.OperationContents {
+		destination, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixContractHash, randomBytes(r, tezosprotocol.ContractHashLen))
+		if err != nil {
+			panic(err)
+		}
+		return &tezosprotocol.IncreasePaidStorage{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+			Amount:       randomZarith(r),
+			Destination:  tezosprotocol.ContractID(destination),
+		}
+	},
+	tezosprotocol.ContentsTagTransferTicket: func(r *rand.Rand) tezosprotocol.OperationContents {
+		contents := tezosprotocol.MichelineBytes(randomBytes(r, r.Intn(64)))
+		return &tezosprotocol.TransferTicket{
+			Source:         randomImplicitContractID(r),
+			Fee:            randomZarith(r),
+			Counter:        randomZarith(r),
+			GasLimit:       randomZarith(r),
+			StorageLimit:   randomZarith(r),
+			TicketContents: &contents,
+			TicketTy:       &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_bytes},
+			TicketTicketer: randomContractID(r),
+			TicketAmount:   randomZarith(r),
+			Destination:    randomContractID(r),
+			Entrypoint:     tezosprotocol.EntrypointDefault,
+		}
+	},
+	tezosprotocol.ContentsTagSmartRollupOriginate: func(r *rand.Rand) tezosprotocol.OperationContents {
+		originate := &tezosprotocol.SmartRollupOriginate{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+			PVMKind:      tezosprotocol.PVMKindWasm,
+			Kernel:       randomBytes(r, r.Intn(64)),
+			ParametersTy: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_bytes},
+		}
+		if r.Intn(2) == 0 {
+			originate.Whitelist = []tezosprotocol.ContractID{}
+			for i := r.Intn(3); i > 0; i-- {
+				originate.Whitelist = append(originate.Whitelist, randomImplicitContractID(r))
+			}
+		}
+		return originate
+	},
+	tezosprotocol.ContentsTagSmartRollupAddMessages: func(r *rand.Rand) tezosprotocol.OperationContents {
+		addMessages := &tezosprotocol.SmartRollupAddMessages{
+			Source:       randomImplicitContractID(r),
+			Fee:          randomZarith(r),
+			Counter:      randomZarith(r),
+			GasLimit:     randomZarith(r),
+			StorageLimit: randomZarith(r),
+		}
+		for i := r.Intn(4); i > 0; i-- {
+			addMessages.Messages = append(addMessages.Messages, randomBytes(r, r.Intn(32)))
+		}
+		return addMessages
+	},
+	tezosprotocol.ContentsTagEndorsement: func(r *rand.Rand) tezosprotocol.OperationContents {
+		return &tezosprotocol.Endorsement{Level: r.Int31()}
+	},
+	tezosprotocol.ContentsTagDoubleBakingEvidence: func(r *rand.Rand) tezosprotocol.OperationContents {
+		return &tezosprotocol.DoubleBakingEvidence{
+			BlockHeader1: randomBlockHeader(r),
+			BlockHeader2: randomBlockHeader(r),
+		}
+	},
+	tezosprotocol.ContentsTagConsensusEndorsement: func(r *rand.Rand) tezosprotocol.OperationContents {
+		endorsement := &tezosprotocol.ConsensusEndorsement{
+			Slot:  uint16(r.Intn(1 << 16)),
+			Level: r.Int31(),
+			Round: r.Int31(),
+		}
+		r.Read(endorsement.BlockPayloadHash[:]) //nolint:errcheck
+		return endorsement
+	},
+	tezosprotocol.ContentsTagActivateAccount: func(r *rand.Rand) tezosprotocol.OperationContents {
+		pubKeyHash, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixEd25519PublicKeyHash, randomBytes(r, tezosprotocol.PubKeyHashLen))
+		if err != nil {
+			panic(err)
+		}
+		activation := &tezosprotocol.AccountActivation{PublicKeyHash: tezosprotocol.ContractID(pubKeyHash)}
+		r.Read(activation.Secret[:]) //nolint:errcheck
+		return activation
+	},
+	tezosprotocol.ContentsTagSeedNonceRevelation: func(r *rand.Rand) tezosprotocol.OperationContents {
+		revelation := &tezosprotocol.SeedNonceRevelation{Level: r.Int31()}
+		r.Read(revelation.Nonce[:]) //nolint:errcheck
+		return revelation
+	},
+	tezosprotocol.ContentsTagFailingNoop: func(r *rand.Rand) tezosprotocol.OperationContents {
+		return &tezosprotocol.FailingNoop{Arbitrary: randomBytes(r, r.Intn(64))}
+	},
+	tezosprotocol.ContentsTagProposals: func(r *rand.Rand) tezosprotocol.OperationContents {
+		proposals := &tezosprotocol.Proposals{
+			Source: randomImplicitContractID(r),
+			Period: r.Int31(),
+		}
+		for i := r.Intn(4); i > 0; i-- {
+			encoded, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixProtocolHash, randomBytes(r, tezosprotocol.ProtocolHashLen))
+			if err != nil {
+				panic(err)
+			}
+			proposals.Proposals = append(proposals.Proposals, tezosprotocol.ProtocolHash(encoded))
+		}
+		return proposals
+	},
+}
+
+func TestContentsRoundTrip(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(1)) //nolint:gosec
+	for tag, generate := range contentGenerators {
+		for i := 0; i < roundTripIterations; i++ {
+			original := generate(r)
+			require.Equal(tag, original.GetTag())
+			encoded, err := original.MarshalBinary()
+			require.NoError(err, "%s", original)
+
+			decoded := reflect.New(reflect.TypeOf(original).Elem()).Interface().(tezosprotocol.OperationContents)
+			require.NoError(decoded.UnmarshalBinary(encoded), "%s", original)
+			reencoded, err := decoded.MarshalBinary()
+			require.NoError(err, "%s", decoded)
+			require.True(bytes.Equal(encoded, reencoded), "%s\n%x\n%x", original, encoded, reencoded)
+		}
+	}
+}
+
+func randomBytes(r *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	r.Read(b) //nolint:errcheck
+	return b
+}
+
+func randomZarith(r *rand.Rand) *big.Int {
+	return new(big.Int).SetBytes(randomBytes(r, r.Intn(10)))
+}
+
+func randomImplicitContractID(r *rand.Rand) tezosprotocol.ContractID {
+	prefixes := []tezosprotocol.Base58CheckPrefix{
+		tezosprotocol.PrefixEd25519PublicKeyHash,
+		tezosprotocol.PrefixSecp256k1PublicKeyHash,
+		tezosprotocol.PrefixP256PublicKeyHash,
+	}
+	encoded, err := tezosprotocol.Base58CheckEncode(prefixes[r.Intn(len(prefixes))], randomBytes(r, tezosprotocol.PubKeyHashLen))
+	if err != nil {
+		panic(err)
+	}
+	return tezosprotocol.ContractID(encoded)
+}
+
+func randomContractID(r *rand.Rand) tezosprotocol.ContractID {
+	if r.Intn(2) == 0 {
+		return randomImplicitContractID(r)
+	}
+	encoded, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixContractHash, randomBytes(r, tezosprotocol.ContractHashLen))
+	if err != nil {
+		panic(err)
+	}
+	return tezosprotocol.ContractID(encoded)
+}
+
+func randomPublicKey(r *rand.Rand) tezosprotocol.PublicKey {
+	encoded, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixEd25519PublicKey, randomBytes(r, 32))
+	if err != nil {
+		panic(err)
+	}
+	return tezosprotocol.PublicKey(encoded)
+}
+
+func randomBlockHeader(r *rand.Rand) tezosprotocol.BlockHeader {
+	randomHash := func(prefix tezosprotocol.Base58CheckPrefix, length int) string {
+		encoded, err := tezosprotocol.Base58CheckEncode(prefix, randomBytes(r, length))
+		if err != nil {
+			panic(err)
+		}
+		return encoded
+	}
+	header := tezosprotocol.BlockHeader{
+		Level:          r.Int31(),
+		Proto:          uint8(r.Intn(256)),
+		Predecessor:    tezosprotocol.BranchID(randomHash(tezosprotocol.PrefixBlockHash, tezosprotocol.BlockHashLen)),
+		Timestamp:      r.Int63(),
+		ValidationPass: uint8(r.Intn(256)),
+		OperationsHash: tezosprotocol.OperationListListHash(randomHash(tezosprotocol.PrefixOperationListListHash, tezosprotocol.OperationListListHashLen)),
+		Context:        tezosprotocol.ContextHash(randomHash(tezosprotocol.PrefixContextHash, tezosprotocol.ContextHashLen)),
+	}
+	for i := r.Intn(3); i > 0; i-- {
+		header.Fitness = append(header.Fitness, randomBytes(r, r.Intn(8)))
+	}
+	if n := r.Intn(96); n > 0 {
+		header.ProtocolData = randomBytes(r, n)
+	}
+	return header
+}
@@ -0,0 +1,89 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// SeedNonceLen is the length in bytes of a seed nonce
+const SeedNonceLen = 32
+
+// SeedNonceRevelation models the tezos seed_nonce_revelation operation type, with
+// which a baker reveals the seed nonce committed to in a block it baked
+type SeedNonceRevelation struct {
+	Level int32
+	Nonce [SeedNonceLen]byte
+}
+
+func (s *SeedNonceRevelation) String() string {
+	return fmt.Sprintf("%#v", s)
+}
+
+// GetTag implements OperationContents
+func (s *SeedNonceRevelation) GetTag() ContentsTag {
+	return ContentsTagSeedNonceRevelation
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (s *SeedNonceRevelation) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(s.GetTag()))
+
+	// level
+	err := binary.Write(&buf, binary.BigEndian, s.Level)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write level: %w", err)
+	}
+
+	// nonce
+	buf.Write(s.Nonce[:])
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *SeedNonceRevelation) UnmarshalBinary(data []byte) error {
+	_, err := s.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the seed nonce revelation at the start of data and returns the number
+// of bytes it occupies
+func (s *SeedNonceRevelation) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagSeedNonceRevelation {
+		return 0, xerrors.Errorf("invalid tag for seed nonce revelation. Expected %d, saw %d", ContentsTagSeedNonceRevelation, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// level
+	level, err := readInt32(dataPtr[:4])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal level: %w", err)
+	}
+	s.Level = level
+	dataPtr = dataPtr[4:]
+
+	// nonce
+	copy(s.Nonce[:], dataPtr[:SeedNonceLen])
+	dataPtr = dataPtr[SeedNonceLen:]
+
+	return len(data) - len(dataPtr), nil
+}
@@ -0,0 +1,32 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const seedNonceRevelationHex = "01" + "0001e240" + "9b7a6c1e0c5c4f3a2d0e1b8f7c6d5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f"
+
+func TestEncodeSeedNonceRevelation(t *testing.T) {
+	require := require.New(t)
+	revelation := &tezosprotocol.SeedNonceRevelation{Level: 123456}
+	copy(revelation.Nonce[:], fromHex("9b7a6c1e0c5c4f3a2d0e1b8f7c6d5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f"))
+	encodedBytes, err := revelation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(seedNonceRevelationHex, hex.EncodeToString(encodedBytes))
+}
+
+func TestDecodeSeedNonceRevelation(t *testing.T) {
+	require := require.New(t)
+	branch := fromHex("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f")
+	operation := tezosprotocol.Operation{}
+	require.NoError(operation.UnmarshalBinary(append(branch, fromHex(seedNonceRevelationHex)...)))
+	require.Len(operation.Contents, 1)
+	revelation, ok := operation.Contents[0].(*tezosprotocol.SeedNonceRevelation)
+	require.True(ok)
+	require.Equal(int32(123456), revelation.Level)
+	require.Equal("9b7a6c1e0c5c4f3a2d0e1b8f7c6d5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f", hex.EncodeToString(revelation.Nonce[:]))
+}
@@ -0,0 +1,173 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// SetDepositsLimit models the tezos set_deposits_limit operation type, with which a
+// delegate caps the deposits frozen for baking and endorsing. A nil Limit removes
+// the cap.
+type SetDepositsLimit struct {
+	Source       ContractID
+	Fee          *big.Int
+	Counter      *big.Int
+	GasLimit     *big.Int
+	StorageLimit *big.Int
+	Limit        *big.Int
+}
+
+func (r *SetDepositsLimit) String() string {
+	return fmt.Sprintf("%#v", r)
+}
+
+// GetTag implements OperationContents
+func (r *SetDepositsLimit) GetTag() ContentsTag {
+	return ContentsTagSetDepositsLimit
+}
+
+// GetSource returns the operation's source
+func (r *SetDepositsLimit) GetSource() ContractID {
+	return r.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (r *SetDepositsLimit) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(r.GetTag()))
+
+	// source
+	sourceBytes, err := r.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// fee
+	fee, err := zarith.Encode(r.Fee)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Fee: %w", err)
+	}
+	buf.Write(fee)
+
+	// counter
+	counter, err := zarith.Encode(r.Counter)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Counter: %w", err)
+	}
+	buf.Write(counter)
+
+	// gas limit
+	gasLimit, err := zarith.Encode(r.GasLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write GasLimit: %w", err)
+	}
+	buf.Write(gasLimit)
+
+	// storage limit
+	storageLimit, err := zarith.Encode(r.StorageLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write StorageLimit: %w", err)
+	}
+	buf.Write(storageLimit)
+
+	// limit
+	hasLimit := r.Limit != nil
+	buf.WriteByte(serializeBoolean(hasLimit))
+	if hasLimit {
+		limit, err := zarith.Encode(r.Limit)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write Limit: %w", err)
+		}
+		buf.Write(limit)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *SetDepositsLimit) UnmarshalBinary(data []byte) error {
+	_, err := r.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the set deposits limit at the start of data and returns the number
+// of bytes it occupies
+func (r *SetDepositsLimit) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagSetDepositsLimit {
+		return 0, xerrors.Errorf("invalid tag for set deposits limit. Expected %d, saw %d", ContentsTagSetDepositsLimit, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = r.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// fee
+	var bytesRead int
+	r.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// counter
+	r.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// gas limit
+	r.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// storage limit
+	r.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// limit
+	hasLimit, err := deserializeBoolean(dataPtr[0])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to deserialize presence of field \"limit\": %w", err)
+	}
+	dataPtr = dataPtr[1:]
+	r.Limit = nil
+	if hasLimit {
+		r.Limit, bytesRead, err = zarith.ReadNext(dataPtr)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal limit: %w", err)
+		}
+		dataPtr = dataPtr[bytesRead:]
+	}
+
+	return len(data) - len(dataPtr), nil
+}
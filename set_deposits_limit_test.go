@@ -0,0 +1,42 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDepositsLimit(t *testing.T) {
+	require := require.New(t)
+	setDepositsLimit := &tezosprotocol.SetDepositsLimit{
+		Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Fee:          big.NewInt(1000),
+		Counter:      big.NewInt(2),
+		GasLimit:     big.NewInt(1500),
+		StorageLimit: big.NewInt(0),
+		Limit:        big.NewInt(1000000),
+	}
+
+	// set
+	encodedBytes, err := setDepositsLimit.MarshalBinary()
+	require.NoError(err)
+	require.Equal("700002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b00"+"ff"+"c0843d", hex.EncodeToString(encodedBytes))
+	decoded := &tezosprotocol.SetDepositsLimit{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(setDepositsLimit, decoded)
+
+	// unset
+	setDepositsLimit.Limit = nil
+	encodedBytes, err = setDepositsLimit.MarshalBinary()
+	require.NoError(err)
+	require.Equal("700002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b00"+"00", hex.EncodeToString(encodedBytes))
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(setDepositsLimit, decoded)
+
+	// invalid presence flag
+	encodedBytes[len(encodedBytes)-1] = 1
+	require.Error(decoded.UnmarshalBinary(encodedBytes))
+}
@@ -1,6 +1,44 @@
 package tezosprotocol
 
-import "golang.org/x/xerrors"
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/xerrors"
+)
+
+// SignatureScheme identifies the curve of the key that produced a signature
+type SignatureScheme int
+
+// SignatureScheme values
+const (
+	// SignatureSchemeGeneric is used when the curve is unknown
+	SignatureSchemeGeneric SignatureScheme = iota
+	SignatureSchemeEd25519
+	SignatureSchemeSecp256k1
+	SignatureSchemeP256
+)
+
+// SignaturePrefix returns the base58check prefix of signatures in this scheme
+func (s SignatureScheme) SignaturePrefix() (Base58CheckPrefix, error) {
+	switch s {
+	case SignatureSchemeGeneric:
+		return PrefixGenericSignature, nil
+	case SignatureSchemeEd25519:
+		return PrefixEd25519Signature, nil
+	case SignatureSchemeSecp256k1:
+		return PrefixSecp256k1Signature, nil
+	case SignatureSchemeP256:
+		return PrefixP256Signature, nil
+	default:
+		return 0, xerrors.Errorf("unknown signature scheme %d", s)
+	}
+}
 
 // Signature is a tezos base58check encoded signature. It may be in either the generic or non-generic format.
 type Signature string
@@ -15,6 +53,69 @@ func (s Signature) MarshalBinary() ([]byte, error) {
 	case PrefixEd25519Signature, PrefixP256Signature, PrefixSecp256k1Signature, PrefixGenericSignature:
 		return payload, nil
 	default:
-		return nil, xerrors.Errorf("unexpected base58check prefix (%s) for signature %s", prefix.String(), s)
+		return nil, xerrors.Errorf("%w (%s) for signature %s", ErrUnexpectedPrefix, prefix.String(), s)
+	}
+}
+
+// Verify checks that the signature was made by publicKey over message, prefixed
+// with watermark and hashed with blake2b-256 the way tezos signers do. publicKey
+// may be an ed25519, secp256k1 or P256 key, and the signature must either be of
+// the matching type or generic. It returns false if the signature does not match,
+// and an error if the signature or key are malformed or of mismatched types.
+// ECDSA signatures are accepted whether or not s is normalized, although the
+// protocol itself rejects secp256k1 signatures with a high s.
+func (s Signature) Verify(watermark Watermark, message []byte, publicKey crypto.PublicKey) (bool, error) {
+	payloadHash := SignatureHash(watermark, message)
+
+	// verify signature over hash
+	sigPrefix, sigBytes, err := Base58CheckDecode(string(s))
+	if err != nil {
+		return false, xerrors.Errorf("failed to decode signature: %s: %w", s, err)
+	}
+	var ok bool
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if sigPrefix != PrefixEd25519Signature && sigPrefix != PrefixGenericSignature {
+			return false, xerrors.Errorf("signature type %s does not match public key type %T", sigPrefix, publicKey)
+		}
+		ok = ed25519.Verify(key, payloadHash[:], sigBytes)
+	case *ecdsa.PublicKey:
+		if len(sigBytes) != OperationSignatureLen {
+			return false, xerrors.Errorf("invalid signature %s: expected %d bytes, saw %d", s, OperationSignatureLen, len(sigBytes))
+		}
+		sigR, sigS := new(big.Int).SetBytes(sigBytes[:32]), new(big.Int).SetBytes(sigBytes[32:])
+		// (r, s) and (r, n-s) are both valid signatures, so accept those from tools
+		// that don't normalize s to the lower half of the order. The protocol
+		// accepts high s for P256, but it checks secp256k1 signatures with
+		// libsecp256k1, which rejects them: secp256k1 verification here is more
+		// lenient than the protocol's.
+		if n := key.Curve.Params().N; sigS.Cmp(new(big.Int).Rsh(n, 1)) > 0 && sigS.Cmp(n) < 0 {
+			sigS.Sub(n, sigS)
+			sigBytes = serializeECDSASignature(sigR, sigS)
+		}
+		switch key.Curve {
+		case btcec.S256():
+			if sigPrefix != PrefixSecp256k1Signature && sigPrefix != PrefixGenericSignature {
+				return false, xerrors.Errorf("signature type %s does not match public key type secp256k1", sigPrefix)
+			}
+			var rScalar, sScalar btcec.ModNScalar
+			if rScalar.SetByteSlice(sigBytes[:32]) || sScalar.SetByteSlice(sigBytes[32:]) {
+				return false, xerrors.Errorf("invalid signature %s: r or s out of range", s)
+			}
+			x, y := &btcec.FieldVal{}, &btcec.FieldVal{}
+			x.SetByteSlice(key.X.Bytes())
+			y.SetByteSlice(key.Y.Bytes())
+			ok = btcecdsa.NewSignature(&rScalar, &sScalar).Verify(payloadHash[:], btcec.NewPublicKey(x, y))
+		case elliptic.P256():
+			if sigPrefix != PrefixP256Signature && sigPrefix != PrefixGenericSignature {
+				return false, xerrors.Errorf("signature type %s does not match public key type P256", sigPrefix)
+			}
+			ok = ecdsa.Verify(key, payloadHash[:], sigR, sigS)
+		default:
+			return false, xerrors.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+	default:
+		return false, xerrors.Errorf("unsupported public key type: %T", publicKey)
 	}
+	return ok, nil
 }
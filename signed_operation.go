@@ -1,8 +1,11 @@
 package tezosprotocol
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
@@ -24,6 +27,7 @@ type Watermark byte
 // References: https://gitlab.com/tezos/tezos/blob/master/src/lib_crypto/signature.ml#L43
 const (
 	// BlockHeaderWatermark is the special byte prepended to serialized block headers before signing
+	// in protocols before Tenderbake
 	BlockHeaderWatermark Watermark = 1
 	// EndorsementWatermark is the special byte prepended to serialized endorsements before signing
 	EndorsementWatermark Watermark = 2
@@ -35,8 +39,21 @@ const (
 	// yet part of the standard but has some precedent here:
 	// https://tezos.stackexchange.com/questions/1177/whats-the-easiest-way-for-an-account-holder-to-verify-sign-that-they-are-the-ri/1178#1178
 	TextWatermark Watermark = 5
+	// TenderbakeBlockHeaderWatermark is the special byte prepended to serialized block headers
+	// before signing in Tenderbake protocols, which replaced BlockHeaderWatermark
+	TenderbakeBlockHeaderWatermark Watermark = 0x11
 )
 
+// SignatureHash returns the digest that is signed for payload under watermark: the
+// blake2b-256 hash of the watermark byte followed by payload. Every signature in
+// this package is made over such a digest.
+func SignatureHash(watermark Watermark, payload []byte) [32]byte {
+	bytesWithWatermark := make([]byte, 0, 1+len(payload))
+	bytesWithWatermark = append(bytesWithWatermark, byte(watermark))
+	bytesWithWatermark = append(bytesWithWatermark, payload...)
+	return blake2b.Sum256(bytesWithWatermark)
+}
+
 // SignOperation signs the given tezos operation using the provided
 // signing key. The returned bytes are the signed operation, encoded as
 // (operation bytes || signature bytes).
@@ -67,53 +84,127 @@ func (s SignedOperation) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler. In cases where
 // the signature type cannot be inferred, PrefixGenericSignature is used instead.
+//
+// The input must be a signed operation. The trailing OperationSignatureLen bytes
+// are always taken to be the signature, so unsigned operation bytes may be silently
+// mis-parsed as a shorter operation followed by a bogus signature. Use
+// UnmarshalUnsigned for unsigned operations.
 func (s *SignedOperation) UnmarshalBinary(data []byte) error {
-	if len(data) < OperationSignatureLen {
-		return xerrors.Errorf("signed operation too short, probably not a signed operation: %d", len(data))
+	signatureBytes, err := s.unmarshalOperation(data)
+	if err != nil {
+		return err
+	}
+	signaturePrefix, err := inferSignaturePrefix(s.Operation)
+	if err != nil {
+		return err
+	}
+	signature, err := Base58CheckEncode(signaturePrefix, signatureBytes)
+	s.Signature = Signature(signature)
+	return err
+}
+
+// UnmarshalBinaryWithScheme is like UnmarshalBinary, but encodes the signature
+// for the given scheme instead of inferring it from the operation's sources. Use it
+// when the signer's key type is known, e.g. when the source is an originated account.
+func (s *SignedOperation) UnmarshalBinaryWithScheme(data []byte, scheme SignatureScheme) error {
+	signaturePrefix, err := scheme.SignaturePrefix()
+	if err != nil {
+		return err
+	}
+	signatureBytes, err := s.unmarshalOperation(data)
+	if err != nil {
+		return err
+	}
+	signature, err := Base58CheckEncode(signaturePrefix, signatureBytes)
+	s.Signature = Signature(signature)
+	return err
+}
+
+// UnmarshalBinaryWithSignerKey is like UnmarshalBinary, but encodes the signature
+// for the type of signerKey, the key of the operation's signer, instead of
+// inferring it from the operation's sources. This gives the canonical
+// (non-generic) signature even when the source is an originated account.
+func (s *SignedOperation) UnmarshalBinaryWithSignerKey(data []byte, signerKey PublicKey) error {
+	scheme, err := signerKey.signatureScheme()
+	if err != nil {
+		return err
 	}
+	return s.UnmarshalBinaryWithScheme(data, scheme)
+}
 
-	// operation
+// unmarshalOperation parses the operation part of a signed operation into
+// s.Operation and returns the raw signature bytes.
+func (s *SignedOperation) unmarshalOperation(data []byte) ([]byte, error) {
+	if len(data) < OperationSignatureLen {
+		return nil, xerrors.Errorf("signed operation too short, probably not a signed operation: %d", len(data))
+	}
 	operationLen := len(data) - OperationSignatureLen
 	s.Operation = &Operation{}
 	err := s.Operation.UnmarshalBinary(data[:operationLen])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal operation in signed operation: %w", err)
+		return nil, xerrors.Errorf("failed to unmarshal operation in signed operation: %w", err)
+	}
+	if len(s.Operation.Contents) == 0 {
+		return nil, xerrors.New("signed operation has no contents")
 	}
+	return data[operationLen:], nil
+}
 
-	// signature
-	signatureBytes := data[operationLen:]
-	for _, content := range s.Operation.Contents {
+// inferSignaturePrefix picks the signature prefix matching the key type of the
+// first implicit source in the operation. Consensus contents (e.g. endorsements)
+// have no source, so an operation made up entirely of them, or of contents from
+// originated accounts, falls through to the generic prefix.
+func inferSignaturePrefix(operation *Operation) (Base58CheckPrefix, error) {
+	for _, content := range operation.Contents {
 		sourceableContent, ok := content.(interface{ GetSource() ContractID })
 		if ok {
 			sourceContract := sourceableContent.GetSource()
-			var sourceContractType Base58CheckPrefix
-			sourceContractType, _, err = Base58CheckDecode(string(sourceContract))
+			if sourceContract == "" {
+				// consensus contents have no source
+				continue
+			}
+			sourceContractType, _, err := Base58CheckDecode(string(sourceContract))
 			if err != nil {
-				return err
+				return 0, err
 			}
-			var signature string
 			switch sourceContractType {
 			case PrefixEd25519PublicKeyHash:
-				signature, err = Base58CheckEncode(PrefixEd25519Signature, signatureBytes)
-				s.Signature = Signature(signature)
-				return err
+				return PrefixEd25519Signature, nil
 			case PrefixP256PublicKeyHash:
-				signature, err = Base58CheckEncode(PrefixP256Signature, signatureBytes)
-				s.Signature = Signature(signature)
-				return err
+				return PrefixP256Signature, nil
 			case PrefixSecp256k1PublicKeyHash:
-				signature, err = Base58CheckEncode(PrefixSecp256k1Signature, signatureBytes)
-				s.Signature = Signature(signature)
-				return err
+				return PrefixSecp256k1Signature, nil
 			case PrefixContractHash:
 				// manager (signer) not known -- continue searching operation contents
 			}
 		}
 	}
 	// could not determine signature type -- most likely because the source is an originated account
-	signature, err := Base58CheckEncode(PrefixGenericSignature, signatureBytes)
-	s.Signature = Signature(signature)
-	return err
+	return PrefixGenericSignature, nil
+}
+
+// UnmarshalSigned parses a signed operation, as produced by SignedOperation.MarshalBinary.
+// It is equivalent to UnmarshalBinary.
+func (s *SignedOperation) UnmarshalSigned(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// UnmarshalUnsigned parses an unsigned operation, as produced by Operation.MarshalBinary,
+// leaving Signature empty.
+func (s *SignedOperation) UnmarshalUnsigned(data []byte) error {
+	operation := &Operation{}
+	err := operation.UnmarshalBinary(data)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal unsigned operation: %w", err)
+	}
+	s.Operation = operation
+	s.Signature = ""
+	return nil
+}
+
+// IsSigned returns whether the operation carries a signature
+func (s SignedOperation) IsSigned() bool {
+	return s.Signature != ""
 }
 
 // GetHash returns the hash of a signed operation.
@@ -128,19 +219,60 @@ func (s SignedOperation) GetHash() (OperationHash, error) {
 	return hashEncoded, err
 }
 
+// OriginatedAddresses returns the addresses of the accounts originated by the
+// operation's Origination contents, in the order they appear. Addresses derive from
+// the signed operation's hash, so the operation must be signed. Contracts originated
+// by smart contract calls during execution are not included.
+func (s SignedOperation) OriginatedAddresses() ([]ContractID, error) {
+	var originationCount uint32
+	for _, content := range s.Operation.Contents {
+		if _, ok := content.(*Origination); ok {
+			originationCount++
+		}
+	}
+	if originationCount == 0 {
+		return nil, nil
+	}
+	if !s.IsSigned() {
+		return nil, xerrors.New("operation must be signed to derive originated addresses")
+	}
+	operationHash, err := s.GetHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to compute operation hash: %w", err)
+	}
+	return NewContractIDsFromOrigination(operationHash, originationCount)
+}
+
+// HashMatches reports whether nodeHash, the operation hash returned by a node
+// after injection, matches the locally computed hash of the signed operation.
+// A mismatch indicates the node forged the operation differently. It errors if
+// nodeHash is not a valid operation hash.
+func (s SignedOperation) HashMatches(nodeHash string) (bool, error) {
+	nodeHashBytes, err := OperationHash(nodeHash).MarshalBinary()
+	if err != nil {
+		return false, xerrors.Errorf("invalid node operation hash: %w", err)
+	}
+	localHash, err := s.GetHash()
+	if err != nil {
+		return false, xerrors.Errorf("failed to compute operation hash: %w", err)
+	}
+	localHashBytes, err := localHash.MarshalBinary()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(nodeHashBytes, localHashBytes), nil
+}
+
 // SignMessage signs the given text based message using the provided
 // signing key. It returns the base58check-encoded signature which does not include the message.
-// It uses the 0x04 non-standard watermark.
+// It uses the 0x04 non-standard watermark. SignMessageWithFailingNoop is the
+// standard alternative.
 func SignMessage(message string, privateKey PrivateKey) (Signature, error) {
 	return signGeneric(TextWatermark, []byte(message), privateKey)
 }
 
 func signGeneric(watermark Watermark, message []byte, privateKey PrivateKey) (Signature, error) {
-	// prepend the tezos operation watermark
-	bytesWithWatermark := append([]byte{byte(watermark)}, message...)
-
-	// hash unsigned operation
-	payloadHash := blake2b.Sum256(bytesWithWatermark)
+	payloadHash := SignatureHash(watermark, message)
 
 	// sign the hash
 	cryptoPrivateKey, err := privateKey.CryptoPrivateKey()
@@ -152,11 +284,27 @@ func signGeneric(watermark Watermark, message []byte, privateKey PrivateKey) (Si
 		signatureBytes := ed25519.Sign(key, payloadHash[:])
 		signature, err := Base58CheckEncode(PrefixEd25519Signature, signatureBytes)
 		return Signature(signature), err
-	case ecdsa.PrivateKey:
-		btcecPrivKey, _ := btcec.PrivKeyFromBytes(key.D.Bytes())
-		btcecSignature := btcecdsa.Sign(btcecPrivKey, payloadHash[:])
-		signature, err := Base58CheckEncode(PrefixGenericSignature, btcecSignature.Serialize())
-		return Signature(signature), err
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case btcec.S256():
+			btcecPrivKey, _ := btcec.PrivKeyFromBytes(key.D.Bytes())
+			// compact signatures are a recovery byte followed by r || s
+			compactSignature, err := btcecdsa.SignCompact(btcecPrivKey, payloadHash[:], true)
+			if err != nil {
+				return "", xerrors.Errorf("failed to sign: %w", err)
+			}
+			signature, err := Base58CheckEncode(PrefixSecp256k1Signature, compactSignature[1:])
+			return Signature(signature), err
+		case elliptic.P256():
+			r, s, err := signECDSADeterministic(key, payloadHash[:])
+			if err != nil {
+				return "", xerrors.Errorf("failed to sign: %w", err)
+			}
+			signature, err := Base58CheckEncode(PrefixP256Signature, serializeECDSASignature(r, s))
+			return Signature(signature), err
+		default:
+			return "", xerrors.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
 	default:
 		return "", xerrors.Errorf("unsupported private key type: %T", cryptoPrivateKey)
 	}
@@ -167,30 +315,45 @@ func VerifyMessage(message string, signature Signature, publicKey crypto.PublicK
 	return verifyGeneric(TextWatermark, []byte(message), signature, publicKey)
 }
 
-func verifyGeneric(watermark Watermark, message []byte, signature Signature, publicKey crypto.PublicKey) error {
-	// prepend the tezos operation watermark
-	bytesWithWatermark := append([]byte{byte(watermark)}, message...)
-
-	// hash
-	payloadHash := blake2b.Sum256(bytesWithWatermark)
+// Verify checks that the signed operation was signed by publicKey. Generic
+// signatures are checked according to the type of publicKey.
+func (s SignedOperation) Verify(publicKey crypto.PublicKey) error {
+	if s.Operation == nil {
+		return xerrors.New("signed operation has no operation")
+	}
+	operationBytes, err := s.Operation.MarshalBinary()
+	if err != nil {
+		return xerrors.Errorf("failed to marshal operation: %w", err)
+	}
+	return verifyGeneric(OperationWatermark, operationBytes, s.Signature, publicKey)
+}
 
-	// verify signature over hash
-	sigPrefix, sigBytes, err := Base58CheckDecode(string(signature))
+// VerifyMessageWithPublicKey verifies the signature on a human readable message
+// against a base58check encoded tezos public key
+func VerifyMessageWithPublicKey(message string, signature Signature, publicKey PublicKey) error {
+	cryptoPublicKey, err := publicKey.CryptoPublicKey()
 	if err != nil {
-		return xerrors.Errorf("failed to decode signature: %s: %w", signature, err)
+		return xerrors.Errorf("failed to decode public key %s: %w", publicKey, err)
 	}
-	var ok bool
-	switch key := publicKey.(type) {
-	case ed25519.PublicKey:
-		if sigPrefix != PrefixEd25519Signature && sigPrefix != PrefixGenericSignature {
-			return xerrors.Errorf("signature type %s does not match public key type %T", sigPrefix, publicKey)
-		}
-		ok = ed25519.Verify(key, payloadHash[:], sigBytes)
-	default:
-		return xerrors.Errorf("unsupported public key type: %T", publicKey)
+	return VerifyMessage(message, signature, cryptoPublicKey)
+}
+
+func verifyGeneric(watermark Watermark, message []byte, signature Signature, publicKey crypto.PublicKey) error {
+	ok, err := signature.Verify(watermark, message, publicKey)
+	if err != nil {
+		return err
 	}
 	if !ok {
 		return xerrors.Errorf("invalid signature %s for public key %s", signature, publicKey)
 	}
 	return nil
 }
+
+// serializeECDSASignature encodes an ECDSA signature the way tezos does: r and s,
+// each as a 32 byte big-endian integer
+func serializeECDSASignature(r, s *big.Int) []byte {
+	sigBytes := make([]byte, OperationSignatureLen)
+	r.FillBytes(sigBytes[:32])
+	s.FillBytes(sigBytes[32:])
+	return sigBytes
+}
@@ -2,13 +2,17 @@ package tezosprotocol_test
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"encoding/hex"
 	"math/big"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
 )
 
 // checks the SignOperation function against a known operation, private key, and
@@ -69,6 +73,25 @@ func TestGetSignedOperationHash(t *testing.T) {
 	require.Equal(tezosprotocol.OperationHash("onvk5LwVA1AXnUEvcz17HE2jt2DLkYbqxkbboX53utEJQ56sThr"), operationHash)
 }
 
+func TestSignedOperationHashMatches(t *testing.T) {
+	require := require.New(t)
+	signedOperationBytes, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78d0860302c8010080c2d72f0000e7670f32038107a59a2b9cfefae36ea21f5aa63c0065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	require.NoError(err)
+	signedOperation := tezosprotocol.SignedOperation{}
+	require.NoError(signedOperation.UnmarshalBinary(signedOperationBytes))
+
+	matches, err := signedOperation.HashMatches("onvk5LwVA1AXnUEvcz17HE2jt2DLkYbqxkbboX53utEJQ56sThr")
+	require.NoError(err)
+	require.True(matches)
+
+	matches, err = signedOperation.HashMatches("onmjDWrnLtvHqYtdGPSRSdMjCK1KgHi7YJLdKauuNRfkjTA6Voo")
+	require.NoError(err)
+	require.False(matches)
+
+	_, err = signedOperation.HashMatches("BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2")
+	require.Error(err)
+}
+
 func TestMessageSignatureVerification(t *testing.T) {
 	require := require.New(t)
 	msg := "Hi, my name is Werner Brandes. My voice is my passport. Verify Me."
@@ -81,3 +104,401 @@ func TestMessageSignatureVerification(t *testing.T) {
 	err = tezosprotocol.VerifyMessage(msg, sig, cryptoPublicKey)
 	require.NoError(err)
 }
+
+func TestMessageSignatureVerificationECDSA(t *testing.T) {
+	require := require.New(t)
+	msg := "Hi, my name is Werner Brandes. My voice is my passport. Verify Me."
+	scalar := bytes.Repeat([]byte{7}, 32)
+	secp256k1Key, _ := btcec.PrivKeyFromBytes(scalar)
+	p256Key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(scalar)}
+	p256Key.Curve = elliptic.P256()
+	p256Key.X, p256Key.Y = elliptic.P256().ScalarBaseMult(scalar)
+	for _, testCase := range []struct {
+		cryptoPrivateKey *ecdsa.PrivateKey
+		signaturePrefix  tezosprotocol.Base58CheckPrefix
+	}{
+		{secp256k1Key.ToECDSA(), tezosprotocol.PrefixSecp256k1Signature},
+		{p256Key, tezosprotocol.PrefixP256Signature},
+	} {
+		privateKey, err := tezosprotocol.NewPrivateKeyFromCryptoPrivateKey(testCase.cryptoPrivateKey)
+		require.NoError(err)
+		sig, err := tezosprotocol.SignMessage(msg, privateKey)
+		require.NoError(err)
+		sigPrefix, sigBytes, err := tezosprotocol.Base58CheckDecode(string(sig))
+		require.NoError(err)
+		require.Equal(testCase.signaturePrefix, sigPrefix)
+		require.NoError(tezosprotocol.VerifyMessage(msg, sig, &testCase.cryptoPrivateKey.PublicKey))
+		require.Error(tezosprotocol.VerifyMessage(msg+".", sig, &testCase.cryptoPrivateKey.PublicKey))
+
+		// generic signatures are checked against the key type
+		genericSig, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixGenericSignature, sigBytes)
+		require.NoError(err)
+		require.NoError(tezosprotocol.VerifyMessage(msg, tezosprotocol.Signature(genericSig), &testCase.cryptoPrivateKey.PublicKey))
+	}
+}
+
+func TestMessageSignatureVerificationWithPublicKey(t *testing.T) {
+	require := require.New(t)
+	msg := "Hi, my name is Werner Brandes. My voice is my passport. Verify Me."
+	_, edPrivateKey, err := ed25519.GenerateKey(bytes.NewReader(randSeed))
+	require.NoError(err)
+	edKey, err := tezosprotocol.NewPrivateKeyFromCryptoPrivateKey(edPrivateKey)
+	require.NoError(err)
+	// other keys of the same curves
+	scalar := bytes.Repeat([]byte{7}, 32)
+	otherSecp256k1Key, err := tezosprotocol.NewPublicKeyFromCryptoPublicKey(&ecdsaPrivateKeyFromScalar(btcec.S256(), scalar).PublicKey)
+	require.NoError(err)
+	otherP256Key, err := tezosprotocol.NewPublicKeyFromCryptoPublicKey(&ecdsaPrivateKeyFromScalar(elliptic.P256(), scalar).PublicKey)
+	require.NoError(err)
+
+	for _, testCase := range []struct {
+		privateKey     tezosprotocol.PrivateKey
+		otherPublicKey tezosprotocol.PublicKey
+	}{
+		{edKey, "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"},
+		{"spsk1S1KpLsBEXYYw3nQEGHdNQDTjpBsJH9Y86XZVJNobHFkxezaPv", otherSecp256k1Key},
+		{"p2sk2Mg6PgZcQ3hvj3SV6CXZvSGthGM9T91YENMMAwemHKx2AJRxU6", otherP256Key},
+	} {
+		// sppk and p2pk public keys are compressed points, decompressed to verify
+		publicKey, err := testCase.privateKey.PublicKey()
+		require.NoError(err)
+		sig, err := tezosprotocol.SignMessage(msg, testCase.privateKey)
+		require.NoError(err)
+		require.NoError(tezosprotocol.VerifyMessageWithPublicKey(msg, sig, publicKey), "%s", publicKey)
+		require.Error(tezosprotocol.VerifyMessageWithPublicKey(msg+".", sig, publicKey), "%s", publicKey)
+		require.Error(tezosprotocol.VerifyMessageWithPublicKey(msg, sig, testCase.otherPublicKey), "%s", publicKey)
+	}
+}
+
+func TestDecodeSignedConsensusOperation(t *testing.T) {
+	require := require.New(t)
+	// branch || endorsement(level=450000) || signature
+	signedOperationBytes, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	require.NoError(err)
+	signedOperation := tezosprotocol.SignedOperation{}
+	require.NoError(signedOperation.UnmarshalBinary(signedOperationBytes))
+	require.Len(signedOperation.Operation.Contents, 1)
+	require.IsType(&tezosprotocol.Endorsement{}, signedOperation.Operation.Contents[0])
+
+	// endorsements have no source, so the signature type can't be inferred
+	sigPrefix, _, err := tezosprotocol.Base58CheckDecode(string(signedOperation.Signature))
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrefixGenericSignature, sigPrefix)
+	reencoded, err := signedOperation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(signedOperationBytes, reencoded)
+}
+
+func TestUnmarshalSignedVersusUnsigned(t *testing.T) {
+	require := require.New(t)
+	// an unsigned operation whose final content happens to be exactly
+	// OperationSignatureLen bytes long
+	operation := &tezosprotocol.Operation{
+		Branch: tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{
+			&tezosprotocol.Transaction{
+				Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+				Fee:          big.NewInt(50000),
+				Counter:      big.NewInt(24999999),
+				GasLimit:     big.NewInt(10207),
+				StorageLimit: big.NewInt(0),
+				Amount:       big.NewInt(100000000),
+				Destination:  tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"),
+			},
+			&tezosprotocol.Revelation{
+				Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+				Fee:          big.NewInt(1266),
+				Counter:      big.NewInt(25000000),
+				GasLimit:     big.NewInt(10000),
+				StorageLimit: big.NewInt(0),
+				PublicKey:    tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"),
+			},
+		},
+	}
+	revelationBytes, err := operation.Contents[1].MarshalBinary()
+	require.NoError(err)
+	require.Len(revelationBytes, tezosprotocol.OperationSignatureLen)
+	operationBytes, err := operation.MarshalBinary()
+	require.NoError(err)
+
+	// failure mode: the revelation is silently mistaken for a signature
+	misparsed := tezosprotocol.SignedOperation{}
+	require.NoError(misparsed.UnmarshalBinary(operationBytes))
+	require.Len(misparsed.Operation.Contents, 1)
+	require.True(misparsed.IsSigned())
+
+	// parsing explicitly as unsigned recovers both contents
+	unsigned := tezosprotocol.SignedOperation{}
+	require.NoError(unsigned.UnmarshalUnsigned(operationBytes))
+	require.Len(unsigned.Operation.Contents, 2)
+	require.IsType(&tezosprotocol.Revelation{}, unsigned.Operation.Contents[1])
+	require.False(unsigned.IsSigned())
+	reencoded, err := unsigned.Operation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(operationBytes, reencoded)
+
+	// and signed bytes parse the same through either signed entrypoint
+	privateKey := tezosprotocol.PrivateKey("edskRwAubEVzMEsaPYnTx3DCttC8zYrGjzPMzTfDr7jfDaihYuh95CFrrYj6kyJoqYhycQPXMZHsZR5mPQRtDgjY6KHJxpeKnZ")
+	signedOperation, err := tezosprotocol.SignOperation(operation, privateKey)
+	require.NoError(err)
+	signedOperationBytes, err := signedOperation.MarshalBinary()
+	require.NoError(err)
+	signed := tezosprotocol.SignedOperation{}
+	require.NoError(signed.UnmarshalSigned(signedOperationBytes))
+	require.Len(signed.Operation.Contents, 2)
+	require.Equal(signedOperation.Signature, signed.Signature)
+}
+
+// simulates an air-gapped signing workflow in which the library forges the
+// operation and an offline device signs the exported payload.
+func TestOfflineSigningWorkflow(t *testing.T) {
+	require := require.New(t)
+	operation := &tezosprotocol.Operation{
+		Branch: tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{
+			&tezosprotocol.Revelation{
+				Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+				Fee:          big.NewInt(1257),
+				Counter:      big.NewInt(1),
+				GasLimit:     big.NewInt(10000),
+				StorageLimit: big.NewInt(0),
+				PublicKey:    tezosprotocol.PublicKey("edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"),
+			},
+			&tezosprotocol.Transaction{
+				Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+				Fee:          big.NewInt(50000),
+				Counter:      big.NewInt(2),
+				GasLimit:     big.NewInt(200),
+				StorageLimit: big.NewInt(0),
+				Amount:       big.NewInt(100000000),
+				Destination:  tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"),
+			},
+		},
+	}
+
+	// online: export the payload
+	payload, err := operation.SigningPayload()
+	require.NoError(err)
+	require.Equal(byte(tezosprotocol.OperationWatermark), payload[0])
+	sigHash, err := operation.SignatureHash()
+	require.NoError(err)
+	payloadHash := blake2b.Sum256(payload)
+	require.Equal(sigHash, payloadHash[:])
+	require.Equal(payloadHash, tezosprotocol.SignatureHash(tezosprotocol.OperationWatermark, payload[1:]))
+
+	// offline: sign the payload hash
+	privateKey := tezosprotocol.PrivateKey("edskRwAubEVzMEsaPYnTx3DCttC8zYrGjzPMzTfDr7jfDaihYuh95CFrrYj6kyJoqYhycQPXMZHsZR5mPQRtDgjY6KHJxpeKnZ")
+	cryptoPrivateKey, err := privateKey.CryptoPrivateKey()
+	require.NoError(err)
+	signatureBytes := ed25519.Sign(cryptoPrivateKey.(ed25519.PrivateKey), payloadHash[:])
+	signature, err := tezosprotocol.Base58CheckEncode(tezosprotocol.PrefixEd25519Signature, signatureBytes)
+	require.NoError(err)
+
+	// online: import the signature
+	signedOperation := operation.AttachSignature(tezosprotocol.Signature(signature))
+	signedOperationBytes, err := signedOperation.MarshalBinary()
+	require.NoError(err)
+	expected := "e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78d0860302c8010080c2d72f0000e7670f32038107a59a2b9cfefae36ea21f5aa63c0065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308"
+	require.Equal(expected, hex.EncodeToString(signedOperationBytes))
+}
+
+func TestUnmarshalSignedOperationWithScheme(t *testing.T) {
+	require := require.New(t)
+	// branch || endorsement(level=450000) || signature
+	signedOperationBytes, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	require.NoError(err)
+
+	// the heuristic falls back to a generic signature, but the scheme is known
+	signedOperation := tezosprotocol.SignedOperation{}
+	require.NoError(signedOperation.UnmarshalBinaryWithScheme(signedOperationBytes, tezosprotocol.SignatureSchemeEd25519))
+	sigPrefix, _, err := tezosprotocol.Base58CheckDecode(string(signedOperation.Signature))
+	require.NoError(err)
+	require.Equal(tezosprotocol.PrefixEd25519Signature, sigPrefix)
+	reencoded, err := signedOperation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(signedOperationBytes, reencoded)
+
+	require.Error(signedOperation.UnmarshalBinaryWithScheme(signedOperationBytes, tezosprotocol.SignatureScheme(42)))
+}
+
+func TestUnmarshalSignedOperationWithSignerKey(t *testing.T) {
+	require := require.New(t)
+	// branch || endorsement(level=450000) || signature
+	signedOperationBytes, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f000006ddd065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	require.NoError(err)
+
+	tests := map[tezosprotocol.PublicKey]tezosprotocol.Base58CheckPrefix{
+		"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav":  tezosprotocol.PrefixEd25519Signature,
+		"sppk7czDjVPj1o3hVLeErZTi6brjZNYGc6jFWzFVvW3oRnki3XB58Yq": tezosprotocol.PrefixSecp256k1Signature,
+		"p2pk653txU6DqbwmfVrpRjs3kWsMfFZD2bZxuDoMbNbu3FQ4s557mHT": tezosprotocol.PrefixP256Signature,
+	}
+	for signerKey, expectedPrefix := range tests {
+		signedOperation := tezosprotocol.SignedOperation{}
+		require.NoError(signedOperation.UnmarshalBinaryWithSignerKey(signedOperationBytes, signerKey))
+		sigPrefix, _, err := tezosprotocol.Base58CheckDecode(string(signedOperation.Signature))
+		require.NoError(err)
+		require.Equal(expectedPrefix, sigPrefix)
+	}
+
+	signedOperation := tezosprotocol.SignedOperation{}
+	require.Error(signedOperation.UnmarshalBinaryWithSignerKey(signedOperationBytes, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"))
+}
+
+func TestUnmarshalSignedOperationWithoutContents(t *testing.T) {
+	require := require.New(t)
+	// branch || signature
+	signedOperationBytes, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f65667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	require.NoError(err)
+	signedOperation := tezosprotocol.SignedOperation{}
+	err = signedOperation.UnmarshalBinary(signedOperationBytes)
+	require.Error(err)
+	require.Contains(err.Error(), "no contents")
+}
+
+func TestVerifySignedOperation(t *testing.T) {
+	require := require.New(t)
+	signedOperationBytes, err := hex.DecodeString("e655948a282fcfc31b98abe9b37a82038c4c0e9b8e11f60ea0c7b33e6ecc625f6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78d0860302c8010080c2d72f0000e7670f32038107a59a2b9cfefae36ea21f5aa63c0065667ade71f0c28dcd8c6f443be8b2ff9ebe9f3d2bd8a95d8a29df74319ef24e46bb8abe3e2553dec2a81353f059093861229869ad3c468ade4d9366be3e1308")
+	require.NoError(err)
+	signedOperation := tezosprotocol.SignedOperation{}
+	require.NoError(signedOperation.UnmarshalBinary(signedOperationBytes))
+	signer, err := tezosprotocol.PrivateKey("edskRwAubEVzMEsaPYnTx3DCttC8zYrGjzPMzTfDr7jfDaihYuh95CFrrYj6kyJoqYhycQPXMZHsZR5mPQRtDgjY6KHJxpeKnZ").PublicKey()
+	require.NoError(err)
+	publicKey, err := signer.CryptoPublicKey()
+	require.NoError(err)
+	require.NoError(signedOperation.Verify(publicKey))
+
+	// generic signatures are checked against the key type
+	require.NoError(signedOperation.UnmarshalBinaryWithScheme(signedOperationBytes, tezosprotocol.SignatureSchemeGeneric))
+	require.NoError(signedOperation.Verify(publicKey))
+
+	// tampered operation
+	signedOperation.Operation.Transactions()[0].Amount = big.NewInt(1)
+	require.Error(signedOperation.Verify(publicKey))
+
+	// wrong key
+	otherPublicKey, _, err := ed25519.GenerateKey(bytes.NewReader(randSeed))
+	require.NoError(err)
+	require.NoError(signedOperation.UnmarshalBinary(signedOperationBytes))
+	require.Error(signedOperation.Verify(otherPublicKey))
+}
+
+func TestSignatureVerify(t *testing.T) {
+	require := require.New(t)
+	privateKey := tezosprotocol.PrivateKey("edskRc9Pr1NKUW9x6kAZb9cFerBWMo9X9dW4fXwzzL2rvKyKPfdJaJVUcYCfR37sbBujAXJXVJZoCXsUHzfhNcWuqy9aGunQPk")
+	publicKey, err := privateKey.PublicKey()
+	require.NoError(err)
+	cryptoPublicKey, err := publicKey.CryptoPublicKey()
+	require.NoError(err)
+	signature, err := tezosprotocol.SignMessage("hello", privateKey)
+	require.NoError(err)
+
+	ok, err := signature.Verify(tezosprotocol.TextWatermark, []byte("hello"), cryptoPublicKey)
+	require.NoError(err)
+	require.True(ok)
+
+	// wrong message or watermark
+	ok, err = signature.Verify(tezosprotocol.TextWatermark, []byte("goodbye"), cryptoPublicKey)
+	require.NoError(err)
+	require.False(ok)
+	ok, err = signature.Verify(tezosprotocol.OperationWatermark, []byte("hello"), cryptoPublicKey)
+	require.NoError(err)
+	require.False(ok)
+
+	// key of another curve
+	secp256k1PublicKey, err := tezosprotocol.PublicKey("sppk7czDjVPj1o3hVLeErZTi6brjZNYGc6jFWzFVvW3oRnki3XB58Yq").CryptoPublicKey()
+	require.NoError(err)
+	_, err = signature.Verify(tezosprotocol.TextWatermark, []byte("hello"), secp256k1PublicKey)
+	require.Error(err)
+}
+
+func TestSignOperationP256Deterministic(t *testing.T) {
+	require := require.New(t)
+	operation := &tezosprotocol.Operation{
+		Branch: tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{
+			&tezosprotocol.Transaction{
+				Source:       tezosprotocol.ContractID("tz3RD3Sw9BDqeQs1sh3mTMbB8D3jSd8a5GcN"),
+				Fee:          big.NewInt(1266),
+				Counter:      big.NewInt(1),
+				GasLimit:     big.NewInt(10100),
+				StorageLimit: big.NewInt(277),
+				Amount:       big.NewInt(1000000),
+				Destination:  tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+			},
+		},
+	}
+	privateKey := tezosprotocol.PrivateKey("p2sk2Mg6PgZcQ3hvj3SV6CXZvSGthGM9T91YENMMAwemHKx2AJRxU6")
+
+	// RFC 6979 nonce with HMAC-SHA256. The raw s is in the upper half of the curve
+	// order, so this also covers low-S normalization.
+	expected := tezosprotocol.Signature("p2sigr878x2qN7trfPXs9tbALnKyFnveWQoRMfxtXmkTmF25W9wFUiyUZ29nsjtcqMFxxCrQaaCgeb8V9PsfX6RoXudxjgKqZk")
+	for i := 0; i < 3; i++ {
+		signedOperation, err := tezosprotocol.SignOperation(operation, privateKey)
+		require.NoError(err)
+		require.Equal(expected, signedOperation.Signature)
+	}
+
+	publicKey, err := privateKey.PublicKey()
+	require.NoError(err)
+	cryptoPublicKey, err := publicKey.CryptoPublicKey()
+	require.NoError(err)
+	require.NoError(tezosprotocol.SignedOperation{Operation: operation, Signature: expected}.Verify(cryptoPublicKey))
+}
+
+func TestVerifyHighSSignature(t *testing.T) {
+	require := require.New(t)
+	for _, privateKey := range []tezosprotocol.PrivateKey{
+		"spsk1S1KpLsBEXYYw3nQEGHdNQDTjpBsJH9Y86XZVJNobHFkxezaPv",
+		"p2sk2Mg6PgZcQ3hvj3SV6CXZvSGthGM9T91YENMMAwemHKx2AJRxU6",
+	} {
+		signature, err := tezosprotocol.SignMessage("hello", privateKey)
+		require.NoError(err)
+		cryptoPrivateKey, err := privateKey.CryptoPrivateKey()
+		require.NoError(err)
+		publicKey := &cryptoPrivateKey.(*ecdsa.PrivateKey).PublicKey
+
+		// replace s with n - s, which is in the upper half of the curve order
+		prefix, sigBytes, err := tezosprotocol.Base58CheckDecode(string(signature))
+		require.NoError(err)
+		n := publicKey.Curve.Params().N
+		s := new(big.Int).SetBytes(sigBytes[32:])
+		require.True(s.Cmp(new(big.Int).Rsh(n, 1)) <= 0, "signatures are produced with low S")
+		new(big.Int).Sub(n, s).FillBytes(sigBytes[32:])
+		highSSignature, err := tezosprotocol.Base58CheckEncode(prefix, sigBytes)
+		require.NoError(err)
+
+		require.NoError(tezosprotocol.VerifyMessage("hello", tezosprotocol.Signature(highSSignature), publicKey))
+		require.Error(tezosprotocol.VerifyMessage("goodbye", tezosprotocol.Signature(highSSignature), publicKey))
+	}
+}
+
+func TestSignedOperationOriginatedAddresses(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	script := tezosprotocol.ContractScript{Code: []byte{0x02, 0x00, 0x00, 0x00, 0x00}, Storage: []byte{0x03, 0x0b}}
+	operation, err := tezosprotocol.NewOperationBuilder().
+		WithBranch(tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB")).
+		AddOrigination(tezosprotocol.NewOrigination(source, 0, script)).
+		AddTransaction(tezosprotocol.NewTransfer(source, tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"), 1)).
+		AddOrigination(tezosprotocol.NewOrigination(source, 0, script)).
+		Build(big.NewInt(1))
+	require.NoError(err)
+
+	// unsigned operations have no hash to derive from
+	_, err = tezosprotocol.SignedOperation{Operation: operation}.OriginatedAddresses()
+	require.Error(err)
+
+	privateKey := tezosprotocol.PrivateKey("edskRwAubEVzMEsaPYnTx3DCttC8zYrGjzPMzTfDr7jfDaihYuh95CFrrYj6kyJoqYhycQPXMZHsZR5mPQRtDgjY6KHJxpeKnZ")
+	signedOperation, err := tezosprotocol.SignOperation(operation, privateKey)
+	require.NoError(err)
+	originatedAddresses, err := signedOperation.OriginatedAddresses()
+	require.NoError(err)
+	require.Len(originatedAddresses, 2)
+	operationHash, err := signedOperation.GetHash()
+	require.NoError(err)
+	for nonce, originatedAddress := range originatedAddresses {
+		expected, err := tezosprotocol.NewContractIDFromOrigination(operationHash, uint32(nonce))
+		require.NoError(err)
+		require.Equal(expected, originatedAddress)
+	}
+}
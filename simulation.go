@@ -0,0 +1,198 @@
+package tezosprotocol
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+// Safety margins added on top of the gas and storage consumed in a simulation
+const (
+	// SimulationGasMargin is the gas added to each content's simulated consumption
+	SimulationGasMargin = int64(100)
+	// SimulationStorageMargin is the storage in bytes added to each content's simulated
+	// consumption, when it consumes any storage at all
+	SimulationStorageMargin = int64(20)
+)
+
+// RunOperationResult models the response of the run_operation RPC, keeping only the
+// fields needed to size an operation.
+// Reference: https://tezos.gitlab.io/active/rpc.html#post-block-id-helpers-scripts-run-operation
+type RunOperationResult struct {
+	Contents []RunOperationContentsResult `json:"contents"`
+}
+
+// RunOperationContentsResult is the simulated result of one operation content
+type RunOperationContentsResult struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		OperationResult          OperationResult           `json:"operation_result"`
+		InternalOperationResults []InternalOperationResult `json:"internal_operation_results"`
+	} `json:"metadata"`
+}
+
+// InternalOperationResult is the simulated result of an operation emitted by a
+// contract during the execution of an operation content
+type InternalOperationResult struct {
+	Kind   string          `json:"kind"`
+	Result OperationResult `json:"result"`
+}
+
+// OperationResult holds the resources consumed by an applied operation
+type OperationResult struct {
+	Status                       string          `json:"status"`
+	ConsumedGas                  string          `json:"consumed_gas"`
+	ConsumedMilligas             string          `json:"consumed_milligas"`
+	PaidStorageSizeDiff          string          `json:"paid_storage_size_diff"`
+	AllocatedDestinationContract bool            `json:"allocated_destination_contract"`
+	OriginatedContracts          []ContractID    `json:"originated_contracts"`
+	Errors                       json.RawMessage `json:"errors"`
+}
+
+// consumedGas returns the gas consumed, rounded up to a whole gas unit
+func (r OperationResult) consumedGas() (*big.Int, error) {
+	if r.ConsumedMilligas != "" {
+		milligas, ok := new(big.Int).SetString(r.ConsumedMilligas, 10)
+		if !ok {
+			return nil, xerrors.Errorf("invalid consumed_milligas %q", r.ConsumedMilligas)
+		}
+		milligas.Add(milligas, big.NewInt(999))
+		return milligas.Div(milligas, big.NewInt(1000)), nil
+	}
+	return parseOptionalInt("consumed_gas", r.ConsumedGas)
+}
+
+// consumedStorage returns the storage in bytes paid for by the operation,
+// including the storage burned to allocate new accounts
+func (r OperationResult) consumedStorage() (*big.Int, error) {
+	storage, err := parseOptionalInt("paid_storage_size_diff", r.PaidStorageSizeDiff)
+	if err != nil {
+		return nil, err
+	}
+	allocated := int64(len(r.OriginatedContracts))
+	if r.AllocatedDestinationContract {
+		allocated++
+	}
+	return storage.Add(storage, big.NewInt(allocated*NewAccountStorageLimitBytes)), nil
+}
+
+func parseOptionalInt(field string, value string) (*big.Int, error) {
+	if value == "" {
+		return big.NewInt(0), nil
+	}
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, xerrors.Errorf("invalid %s %q", field, value)
+	}
+	return parsed, nil
+}
+
+// AutoFillFees sizes op using a simulation on a node. runOperation is given the
+// forged unsigned operation, as returned by op.MarshalBinary, and must return the
+// node's response to POST /chains/<chain>/blocks/<block>/helpers/scripts/run_operation;
+// keeping the transport with the caller keeps HTTP out of this package. That RPC takes
+// JSON rather than forged bytes: the request body is
+//
+//	{"operation": {"branch": ..., "contents": [...], "signature": ...}, "chain_id": ...}
+//
+// where branch and contents describe the same operation (the node's parse/operations
+// RPC converts forged bytes to that form), signature is any well-formed signature,
+// since the node doesn't check it in a simulation, and chain_id is the chain's
+// ChainID. The gas and storage limits of each manager content are set to the
+// simulated consumption plus SimulationGasMargin and SimulationStorageMargin, and the
+// fees are then set as apportioned by EstimateFees. It errors if any content fails
+// to apply.
+func AutoFillFees(ctx context.Context, op *Operation, runOperation func(forgedOperation []byte) ([]byte, error)) error {
+	opBytes, err := op.MarshalBinary()
+	if err != nil {
+		return xerrors.Errorf("failed to marshal operation: %w", err)
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	responseBytes, err := runOperation(opBytes)
+	if err != nil {
+		return xerrors.Errorf("failed to run operation: %w", err)
+	}
+	var response RunOperationResult
+	if err = json.Unmarshal(responseBytes, &response); err != nil {
+		return xerrors.Errorf("failed to parse run_operation response: %w", err)
+	}
+	if len(response.Contents) != len(op.Contents) {
+		return xerrors.Errorf("run_operation returned %d results for %d contents", len(response.Contents), len(op.Contents))
+	}
+
+	// gas and storage limits
+	for i, content := range op.Contents {
+		fields, ok := getManagerFields(content)
+		if !ok {
+			continue
+		}
+		gas, storage, err := response.Contents[i].consumed()
+		if err != nil {
+			return xerrors.Errorf("content %d: %w", i, err)
+		}
+		*fields.GasLimit = gas.Add(gas, big.NewInt(SimulationGasMargin))
+		if storage.Sign() > 0 {
+			storage.Add(storage, big.NewInt(SimulationStorageMargin))
+		}
+		*fields.StorageLimit = storage
+	}
+
+	// fees. A content's size depends on its fee, so iterate until the fees settle.
+	gasPerContent := make([]*big.Int, len(op.Contents))
+	for i, content := range op.Contents {
+		gasPerContent[i] = big.NewInt(0)
+		if fields, ok := getManagerFields(content); ok && *fields.GasLimit != nil {
+			gasPerContent[i] = *fields.GasLimit
+		}
+	}
+	for {
+		fees, err := EstimateFees(op, gasPerContent)
+		if err != nil {
+			return err
+		}
+		changed := false
+		for i, content := range op.Contents {
+			fields, ok := getManagerFields(content)
+			if !ok {
+				continue
+			}
+			if *fields.Fee == nil || (*fields.Fee).Cmp(fees[i]) != 0 {
+				*fields.Fee = fees[i]
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+}
+
+// consumed returns the gas and storage consumed by the content, including its
+// internal operations
+func (c RunOperationContentsResult) consumed() (*big.Int, *big.Int, error) {
+	results := []OperationResult{c.Metadata.OperationResult}
+	for _, internal := range c.Metadata.InternalOperationResults {
+		results = append(results, internal.Result)
+	}
+	gas, storage := big.NewInt(0), big.NewInt(0)
+	for _, result := range results {
+		if result.Status != "applied" {
+			return nil, nil, xerrors.Errorf("%s simulation status %q: %s", c.Kind, result.Status, result.Errors)
+		}
+		resultGas, err := result.consumedGas()
+		if err != nil {
+			return nil, nil, err
+		}
+		resultStorage, err := result.consumedStorage()
+		if err != nil {
+			return nil, nil, err
+		}
+		gas.Add(gas, resultGas)
+		storage.Add(storage, resultStorage)
+	}
+	return gas, storage, nil
+}
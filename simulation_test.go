@@ -0,0 +1,81 @@
+package tezosprotocol_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const runOperationResponse = `{
+  "contents": [
+    {
+      "kind": "reveal",
+      "metadata": {
+        "operation_result": {"status": "applied", "consumed_milligas": "1000000"}
+      }
+    },
+    {
+      "kind": "transaction",
+      "metadata": {
+        "operation_result": {
+          "status": "applied",
+          "consumed_gas": "1421",
+          "paid_storage_size_diff": "0",
+          "allocated_destination_contract": true
+        }
+      }
+    }
+  ]
+}`
+
+func TestAutoFillFees(t *testing.T) {
+	require := require.New(t)
+	source := tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	revelation := tezosprotocol.NewReveal(source, "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	revelation.Counter = big.NewInt(1)
+	transaction := tezosprotocol.NewTransfer(source, "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", 100000000)
+	transaction.Counter = big.NewInt(2)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{revelation, transaction},
+	}
+	expectedForged, err := operation.MarshalBinary()
+	require.NoError(err)
+
+	runFn := func(forged []byte) ([]byte, error) {
+		require.Equal(expectedForged, forged)
+		return []byte(runOperationResponse), nil
+	}
+	require.NoError(tezosprotocol.AutoFillFees(context.Background(), operation, runFn))
+
+	require.Equal("1100", revelation.GasLimit.String())
+	require.Equal("0", revelation.StorageLimit.String())
+	require.Equal("1521", transaction.GasLimit.String())
+	require.Equal("277", transaction.StorageLimit.String())
+	revelationBytes, err := revelation.MarshalBinary()
+	require.NoError(err)
+	require.Equal(100+int64(len(revelationBytes))+tezosprotocol.BlockHashLen+tezosprotocol.OperationSignatureLen+110, revelation.Fee.Int64())
+	// the flat fee is charged once, to the first content
+	transactionBytes, err := transaction.MarshalBinary()
+	require.NoError(err)
+	require.Equal(int64(len(transactionBytes))+152, transaction.Fee.Int64())
+}
+
+func TestAutoFillFeesFailedSimulation(t *testing.T) {
+	require := require.New(t)
+	transaction := tezosprotocol.NewTransfer("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", 1)
+	transaction.Counter = big.NewInt(1)
+	operation := &tezosprotocol.Operation{
+		Branch:   tezosprotocol.BranchID("BMTiv62VhjkVXZJL9Cu5s56qTAJxyciQB2fzA9vd2EiVMsaucWB"),
+		Contents: []tezosprotocol.OperationContents{transaction},
+	}
+	runFn := func([]byte) ([]byte, error) {
+		return []byte(`{"contents": [{"kind": "transaction", "metadata": {"operation_result": {"status": "failed", "errors": [{"id": "proto.balance_too_low"}]}}}]}`), nil
+	}
+	err := tezosprotocol.AutoFillFees(context.Background(), operation, runFn)
+	require.Error(err)
+	require.Contains(err.Error(), "balance_too_low")
+}
@@ -0,0 +1,178 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// SmartRollupAddMessages models the tezos smart_rollup_add_messages operation type,
+// which appends messages to the shared inbox of all smart rollups
+type SmartRollupAddMessages struct {
+	Source       ContractID
+	Fee          *big.Int
+	Counter      *big.Int
+	GasLimit     *big.Int
+	StorageLimit *big.Int
+	Messages     [][]byte
+}
+
+func (s *SmartRollupAddMessages) String() string {
+	return fmt.Sprintf("%#v", s)
+}
+
+// GetTag implements OperationContents
+func (s *SmartRollupAddMessages) GetTag() ContentsTag {
+	return ContentsTagSmartRollupAddMessages
+}
+
+// GetSource returns the operation's source
+func (s *SmartRollupAddMessages) GetSource() ContractID {
+	return s.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (s *SmartRollupAddMessages) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(s.GetTag()))
+
+	// source
+	sourceBytes, err := s.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// fee
+	fee, err := zarith.Encode(s.Fee)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Fee: %w", err)
+	}
+	buf.Write(fee)
+
+	// counter
+	counter, err := zarith.Encode(s.Counter)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Counter: %w", err)
+	}
+	buf.Write(counter)
+
+	// gas limit
+	gasLimit, err := zarith.Encode(s.GasLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write GasLimit: %w", err)
+	}
+	buf.Write(gasLimit)
+
+	// storage limit
+	storageLimit, err := zarith.Encode(s.StorageLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write StorageLimit: %w", err)
+	}
+	buf.Write(storageLimit)
+
+	// messages: a length-prefixed list of length-prefixed byte strings
+	messagesBuf := bytes.Buffer{}
+	for _, message := range s.Messages {
+		err = binary.Write(&messagesBuf, binary.BigEndian, uint32(len(message)))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write message length: %w", err)
+		}
+		messagesBuf.Write(message)
+	}
+	err = binary.Write(&buf, binary.BigEndian, uint32(messagesBuf.Len()))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write messages length: %w", err)
+	}
+	buf.Write(messagesBuf.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *SmartRollupAddMessages) UnmarshalBinary(data []byte) error {
+	_, err := s.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the smart rollup messages at the start of data and
+// returns the number of bytes they occupy
+func (s *SmartRollupAddMessages) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagSmartRollupAddMessages {
+		return 0, xerrors.Errorf("invalid tag for smart rollup add messages. Expected %d, saw %d", ContentsTagSmartRollupAddMessages, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = s.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// fee
+	var bytesRead int
+	s.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// counter
+	s.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// gas limit
+	s.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// storage limit
+	s.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// messages
+	messagesBytes, err := readLengthPrefixed(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal messages: %w", err)
+	}
+	dataPtr = dataPtr[4+len(messagesBytes):]
+	s.Messages = nil
+	for len(messagesBytes) > 0 {
+		message, err := readLengthPrefixed(messagesBytes)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal message %d: %w", len(s.Messages), err)
+		}
+		s.Messages = append(s.Messages, append([]byte{}, message...))
+		messagesBytes = messagesBytes[4+len(message):]
+	}
+
+	return len(data) - len(dataPtr), nil
+}
@@ -0,0 +1,33 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// SmartRollupAddressLen is the length in bytes of a serialized smart rollup address
+const SmartRollupAddressLen = 20
+
+// SmartRollupAddress encodes a smart rollup address (sr1) in base58check encoding
+type SmartRollupAddress string
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s SmartRollupAddress) MarshalBinary() ([]byte, error) {
+	return DecodeBase58Typed(string(s), PrefixSmartRollupAddress)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *SmartRollupAddress) UnmarshalBinary(data []byte) error {
+	if len(data) != SmartRollupAddressLen {
+		return xerrors.Errorf("expect smart rollup address to be %d bytes but received %d: %w", SmartRollupAddressLen, len(data), ErrUnexpectedLength)
+	}
+	b58checkEncoded, err := Base58CheckEncode(PrefixSmartRollupAddress, data)
+	if err != nil {
+		return err
+	}
+	*s = SmartRollupAddress(b58checkEncoded)
+	return nil
+}
+
+// IsValid returns whether this is a well-formed sr1 address
+func (s SmartRollupAddress) IsValid() bool {
+	_, err := s.MarshalBinary()
+	return err == nil
+}
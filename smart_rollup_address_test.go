@@ -0,0 +1,36 @@
+package tezosprotocol_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmartRollupAddressBinaryRoundTrip(t *testing.T) {
+	require := require.New(t)
+	address := tezosprotocol.SmartRollupAddress("sr17bar6JpYEpsAmkR4MDYUDwxpSiX4RKUH8")
+	require.True(address.IsValid())
+	encoded, err := address.MarshalBinary()
+	require.NoError(err)
+	require.Equal(bytes.Repeat([]byte{0x11}, tezosprotocol.SmartRollupAddressLen), encoded)
+	var decoded tezosprotocol.SmartRollupAddress
+	require.NoError(decoded.UnmarshalBinary(encoded))
+	require.Equal(address, decoded)
+
+	// other addresses are not smart rollup addresses
+	require.False(tezosprotocol.SmartRollupAddress("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq").IsValid())
+	require.Error(decoded.UnmarshalBinary(encoded[1:]))
+}
+
+func TestSmartRollupCommitmentHashBinaryRoundTrip(t *testing.T) {
+	require := require.New(t)
+	commitmentHash := tezosprotocol.SmartRollupCommitmentHash("src12jLsojB83XmKRnVT4ZLyiQcBQMfejEk32qF8ciT3Qt7wzH67bB")
+	encoded, err := commitmentHash.MarshalBinary()
+	require.NoError(err)
+	require.Equal(bytes.Repeat([]byte{0x22}, tezosprotocol.SmartRollupCommitmentHashLen), encoded)
+	var decoded tezosprotocol.SmartRollupCommitmentHash
+	require.NoError(decoded.UnmarshalBinary(encoded))
+	require.Equal(commitmentHash, decoded)
+}
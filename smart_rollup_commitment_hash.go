@@ -0,0 +1,29 @@
+package tezosprotocol
+
+import "golang.org/x/xerrors"
+
+// SmartRollupCommitmentHashLen is the length in bytes of a serialized smart rollup
+// commitment hash
+const SmartRollupCommitmentHashLen = 32
+
+// SmartRollupCommitmentHash encodes a smart rollup commitment hash (src1) in
+// base58check encoding
+type SmartRollupCommitmentHash string
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s SmartRollupCommitmentHash) MarshalBinary() ([]byte, error) {
+	return DecodeBase58Typed(string(s), PrefixSmartRollupCommitmentHash)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *SmartRollupCommitmentHash) UnmarshalBinary(data []byte) error {
+	if len(data) != SmartRollupCommitmentHashLen {
+		return xerrors.Errorf("expect smart rollup commitment hash to be %d bytes but received %d: %w", SmartRollupCommitmentHashLen, len(data), ErrUnexpectedLength)
+	}
+	b58checkEncoded, err := Base58CheckEncode(PrefixSmartRollupCommitmentHash, data)
+	if err != nil {
+		return err
+	}
+	*s = SmartRollupCommitmentHash(b58checkEncoded)
+	return nil
+}
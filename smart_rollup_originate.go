@@ -0,0 +1,244 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// PVMKind identifies the proof-generating virtual machine run by a smart rollup
+type PVMKind byte
+
+// PVMKind values
+const (
+	PVMKindArith PVMKind = 0
+	PVMKindWasm  PVMKind = 1
+	PVMKindRiscv PVMKind = 2
+)
+
+// SmartRollupOriginate models the tezos smart_rollup_originate operation type,
+// which creates a smart rollup running Kernel. Messages sent to the rollup from
+// layer 1 must have type ParametersTy. If Whitelist is non-nil, the rollup is
+// private and only the listed implicit accounts can publish commitments.
+type SmartRollupOriginate struct {
+	Source       ContractID
+	Fee          *big.Int
+	Counter      *big.Int
+	GasLimit     *big.Int
+	StorageLimit *big.Int
+	PVMKind      PVMKind
+	Kernel       []byte
+	ParametersTy MichelineNode
+	Whitelist    []ContractID
+}
+
+func (s *SmartRollupOriginate) String() string {
+	return fmt.Sprintf("%#v", s)
+}
+
+// GetTag implements OperationContents
+func (s *SmartRollupOriginate) GetTag() ContentsTag {
+	return ContentsTagSmartRollupOriginate
+}
+
+// GetSource returns the operation's source
+func (s *SmartRollupOriginate) GetSource() ContractID {
+	return s.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (s *SmartRollupOriginate) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(s.GetTag()))
+
+	// source
+	sourceBytes, err := s.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// fee
+	fee, err := zarith.Encode(s.Fee)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Fee: %w", err)
+	}
+	buf.Write(fee)
+
+	// counter
+	counter, err := zarith.Encode(s.Counter)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Counter: %w", err)
+	}
+	buf.Write(counter)
+
+	// gas limit
+	gasLimit, err := zarith.Encode(s.GasLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write GasLimit: %w", err)
+	}
+	buf.Write(gasLimit)
+
+	// storage limit
+	storageLimit, err := zarith.Encode(s.StorageLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write StorageLimit: %w", err)
+	}
+	buf.Write(storageLimit)
+
+	// pvm kind
+	buf.WriteByte(byte(s.PVMKind))
+
+	// kernel
+	err = binary.Write(&buf, binary.BigEndian, uint32(len(s.Kernel)))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write kernel length: %w", err)
+	}
+	buf.Write(s.Kernel)
+
+	// parameters type
+	parametersTyBytes, err := marshalLengthPrefixedMicheline(s.ParametersTy)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write parameters type: %w", err)
+	}
+	buf.Write(parametersTyBytes)
+
+	// whitelist
+	buf.WriteByte(serializeBoolean(s.Whitelist != nil))
+	if s.Whitelist != nil {
+		whitelistBuf := bytes.Buffer{}
+		for _, member := range s.Whitelist {
+			memberBytes, err := member.EncodePubKeyHash()
+			if err != nil {
+				return nil, xerrors.Errorf("failed to write whitelist: %w", err)
+			}
+			whitelistBuf.Write(memberBytes)
+		}
+		err = binary.Write(&buf, binary.BigEndian, uint32(whitelistBuf.Len()))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to write whitelist length: %w", err)
+		}
+		buf.Write(whitelistBuf.Bytes())
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *SmartRollupOriginate) UnmarshalBinary(data []byte) error {
+	_, err := s.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the smart rollup origination at the start of data and
+// returns the number of bytes it occupies
+func (s *SmartRollupOriginate) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagSmartRollupOriginate {
+		return 0, xerrors.Errorf("invalid tag for smart rollup originate. Expected %d, saw %d", ContentsTagSmartRollupOriginate, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = s.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// fee
+	var bytesRead int
+	s.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// counter
+	s.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// gas limit
+	s.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// storage limit
+	s.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// pvm kind
+	s.PVMKind = PVMKind(dataPtr[0])
+	dataPtr = dataPtr[1:]
+
+	// kernel
+	kernel, err := readLengthPrefixed(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal kernel: %w", err)
+	}
+	s.Kernel = append([]byte{}, kernel...)
+	dataPtr = dataPtr[4+len(kernel):]
+
+	// parameters type
+	s.ParametersTy, bytesRead, err = unmarshalLengthPrefixedMicheline(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal parameters type: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// whitelist
+	hasWhitelist, err := deserializeBoolean(dataPtr[0])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to deserialize presence of field \"whitelist\": %w", err)
+	}
+	dataPtr = dataPtr[1:]
+	s.Whitelist = nil
+	if hasWhitelist {
+		whitelistBytes, err := readLengthPrefixed(dataPtr)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to unmarshal whitelist: %w", err)
+		}
+		if len(whitelistBytes)%TaggedPubKeyHashLen != 0 {
+			return 0, xerrors.Errorf("whitelist length %d is not a multiple of %d", len(whitelistBytes), TaggedPubKeyHashLen)
+		}
+		dataPtr = dataPtr[4+len(whitelistBytes):]
+		s.Whitelist = []ContractID{}
+		for len(whitelistBytes) > 0 {
+			var member ContractID
+			err = member.UnmarshalBinary(whitelistBytes[:TaggedPubKeyHashLen])
+			if err != nil {
+				return 0, xerrors.Errorf("failed to unmarshal whitelist: %w", err)
+			}
+			s.Whitelist = append(s.Whitelist, member)
+			whitelistBytes = whitelistBytes[TaggedPubKeyHashLen:]
+		}
+	}
+
+	return len(data) - len(dataPtr), nil
+}
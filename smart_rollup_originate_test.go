@@ -0,0 +1,69 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmartRollupOriginate(t *testing.T) {
+	require := require.New(t)
+	originate := &tezosprotocol.SmartRollupOriginate{
+		Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Fee:          big.NewInt(1000),
+		Counter:      big.NewInt(2),
+		GasLimit:     big.NewInt(1500),
+		StorageLimit: big.NewInt(100),
+		PVMKind:      tezosprotocol.PVMKindWasm,
+		// the wasm magic number
+		Kernel:       []byte{0x00, 0x61, 0x73, 0x6d},
+		ParametersTy: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_bytes},
+	}
+	encodedBytes, err := originate.MarshalBinary()
+	require.NoError(err)
+	expected := "c80002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b64" + "01" + "000000040061736d" + "000000020369" + "00"
+	require.Equal(expected, hex.EncodeToString(encodedBytes))
+
+	decoded := &tezosprotocol.SmartRollupOriginate{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(originate, decoded)
+
+	// a private rollup
+	originate.Whitelist = []tezosprotocol.ContractID{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}
+	encodedBytes, err = originate.MarshalBinary()
+	require.NoError(err)
+	require.Equal(expected[:len(expected)-2]+"ff"+"00000015"+"0002298c03ed7d454a101eb7022bc95f7e5f41ac78", hex.EncodeToString(encodedBytes))
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(originate, decoded)
+
+	// a truncated kernel
+	require.Error(decoded.UnmarshalBinary(encodedBytes[:32]))
+}
+
+func TestSmartRollupAddMessages(t *testing.T) {
+	require := require.New(t)
+	addMessages := &tezosprotocol.SmartRollupAddMessages{
+		Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Fee:          big.NewInt(1000),
+		Counter:      big.NewInt(2),
+		GasLimit:     big.NewInt(1500),
+		StorageLimit: big.NewInt(0),
+		Messages:     [][]byte{{0x01}, {0x02, 0x03}},
+	}
+	encodedBytes, err := addMessages.MarshalBinary()
+	require.NoError(err)
+	expected := "c90002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b00" + "0000000b" + "0000000101" + "000000020203"
+	require.Equal(expected, hex.EncodeToString(encodedBytes))
+
+	decoded := &tezosprotocol.SmartRollupAddMessages{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(addMessages, decoded)
+
+	// a message overrunning the list
+	malformed, err := hex.DecodeString("c90002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b00" + "00000005" + "0000000201")
+	require.NoError(err)
+	require.Error(decoded.UnmarshalBinary(malformed))
+}
@@ -0,0 +1,43 @@
+// Package testutil contains helpers shared by the tezosprotocol tests.
+package testutil
+
+import (
+	"encoding"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateEnv is the environment variable that, when set to a non-empty value,
+// rewrites golden fixtures with the current forged bytes. An environment variable
+// rather than a flag lets go test ./... pass it to every package, including those
+// that don't import testutil.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// GoldenDir is the directory, relative to the package under test, in which
+// golden fixtures are stored.
+const GoldenDir = "testdata"
+
+// AssertForgeMatchesGolden forges obj and compares the hex encoding of the
+// result to the fixture GoldenDir/<name>.golden. When UpdateEnv is set, the
+// fixture is rewritten instead.
+func AssertForgeMatchesGolden(t *testing.T, name string, obj encoding.BinaryMarshaler) {
+	t.Helper()
+	forged, err := obj.MarshalBinary()
+	require.NoError(t, err, "failed to forge %s", name)
+	actual := hex.EncodeToString(forged)
+
+	path := filepath.Join(GoldenDir, name+".golden")
+	if os.Getenv(UpdateEnv) != "" {
+		require.NoError(t, os.MkdirAll(GoldenDir, 0755))
+		require.NoError(t, os.WriteFile(path, []byte(actual+"\n"), 0644)) //nolint:gosec
+		return
+	}
+	expected, err := os.ReadFile(path) //nolint:gosec
+	require.NoError(t, err, "failed to read golden fixture for %s (set %s to create it)", name, UpdateEnv)
+	require.Equal(t, strings.TrimSpace(string(expected)), actual, "forged bytes for %s differ from %s", name, path)
+}
@@ -18,7 +18,59 @@ type Transaction struct {
 	StorageLimit *big.Int
 	Amount       *big.Int
 	Destination  ContractID
-	Parameters   *TransactionParameters
+	// Parameters are omitted from the encoding when nil, or when they call the default
+	// entrypoint with Unit, which is what a transaction without parameters does.
+	Parameters *TransactionParameters
+}
+
+// defaultUnitParameters is the encoding of Unit passed to the default entrypoint
+var defaultUnitParameters = []byte{byte(EntrypointTagDefault), 0x00, 0x00, 0x00, 0x02, 0x03, 0x0b}
+
+// NewTransfer returns a transaction of amountMutez from source to destination with
+// no parameters. The fee is zero and should be filled in after estimation, and the
+// counter is left unset. The storage limit allows for destination being a new
+// implicit account.
+func NewTransfer(source, destination ContractID, amountMutez int64) *Transaction {
+	return &Transaction{
+		Source:       source,
+		Fee:          big.NewInt(0),
+		GasLimit:     big.NewInt(MinimumTransactionGasLimit),
+		StorageLimit: big.NewInt(NewAccountStorageLimitBytes),
+		Amount:       big.NewInt(amountMutez),
+		Destination:  destination,
+	}
+}
+
+// NewContractCall returns a transaction of amountMutez from source that calls
+// entrypoint of destination with value. The entrypoint is given by name, e.g.
+// "transfer" or "%transfer"; reserved names such as "default" use their compact
+// tags. A nil amount transfers nothing. The fee is zero and the gas and storage
+// limits are the per-operation maximums; all three should be lowered after
+// estimation. The counter is left unset.
+func NewContractCall(source, destination ContractID, amountMutez *big.Int, entrypoint string, value MichelineNode) (*Transaction, error) {
+	parsedEntrypoint, err := ParseEntrypoint(entrypoint)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid entrypoint %q: %w", entrypoint, err)
+	}
+	if value == nil {
+		return nil, xerrors.New("contract call requires a parameter value")
+	}
+	amount := big.NewInt(0)
+	if amountMutez != nil {
+		amount.Set(amountMutez)
+	}
+	return &Transaction{
+		Source:       source,
+		Fee:          big.NewInt(0),
+		GasLimit:     big.NewInt(HardGasLimitPerOperation),
+		StorageLimit: big.NewInt(HardStorageLimitPerOperation),
+		Amount:       amount,
+		Destination:  destination,
+		Parameters: &TransactionParameters{
+			Entrypoint: parsedEntrypoint,
+			Value:      value,
+		},
+	}, nil
 }
 
 func (t *Transaction) String() string {
@@ -35,6 +87,28 @@ func (t *Transaction) GetSource() ContractID {
 	return t.Source
 }
 
+// AmountMutez returns the amount as an int64 number of mutez. It errors if the amount
+// does not fit in an int64.
+func (t *Transaction) AmountMutez() (int64, error) {
+	return mutezToInt64("amount", t.Amount)
+}
+
+// FeeMutez returns the fee as an int64 number of mutez. It errors if the fee
+// does not fit in an int64.
+func (t *Transaction) FeeMutez() (int64, error) {
+	return mutezToInt64("fee", t.Fee)
+}
+
+// SetAmount sets the amount transferred
+func (t *Transaction) SetAmount(amount Mutez) {
+	t.Amount = amount.BigInt()
+}
+
+// SetFee sets the fee
+func (t *Transaction) SetFee(fee Mutez) {
+	t.Fee = fee.BigInt()
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler
 func (t *Transaction) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -91,14 +165,18 @@ func (t *Transaction) MarshalBinary() ([]byte, error) {
 	}
 	buf.Write(destinationBytes)
 
-	// parameters
-	paramsFollow := t.Parameters != nil
-	buf.WriteByte(serializeBoolean(paramsFollow))
-	if paramsFollow {
-		paramsBytes, err := t.Parameters.MarshalBinary()
+	// parameters. Calling the default entrypoint with Unit is the same as passing no
+	// parameters, and octez forges it as such, so those parameters are omitted too.
+	var paramsBytes []byte
+	if t.Parameters != nil {
+		paramsBytes, err = t.Parameters.MarshalBinary()
 		if err != nil {
 			return nil, xerrors.Errorf("failed to write transaction parameters: %w", err)
 		}
+	}
+	paramsFollow := paramsBytes != nil && !bytes.Equal(paramsBytes, defaultUnitParameters)
+	buf.WriteByte(serializeBoolean(paramsFollow))
+	if paramsFollow {
 		buf.Write(paramsBytes)
 	}
 
@@ -106,7 +184,14 @@ func (t *Transaction) MarshalBinary() ([]byte, error) {
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler
-func (t *Transaction) UnmarshalBinary(data []byte) (err error) {
+func (t *Transaction) UnmarshalBinary(data []byte) error {
+	_, err := t.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the transaction at the start of data and returns the number
+// of bytes it occupies
+func (t *Transaction) unmarshalBinary(data []byte) (_ int, err error) {
 	// cleanly recover from out of bounds exceptions
 	defer func() {
 		if err == nil {
@@ -121,14 +206,14 @@ func (t *Transaction) UnmarshalBinary(data []byte) (err error) {
 	// tag
 	tag := ContentsTag(dataPtr[0])
 	if tag != ContentsTagTransaction {
-		return xerrors.Errorf("invalid tag for transaction. Expected %d, saw %d", ContentsTagTransaction, tag)
+		return 0, xerrors.Errorf("invalid tag for transaction. Expected %d, saw %d", ContentsTagTransaction, tag)
 	}
 	dataPtr = dataPtr[1:]
 
 	// source
 	err = t.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal source: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
 	}
 	dataPtr = dataPtr[TaggedPubKeyHashLen:]
 
@@ -136,42 +221,42 @@ func (t *Transaction) UnmarshalBinary(data []byte) (err error) {
 	var bytesRead int
 	t.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal fee: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// counter
 	t.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal counter: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// gas limit
 	t.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// storage limit
 	t.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// amount
 	t.Amount, bytesRead, err = zarith.ReadNext(dataPtr)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal counter: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
 	}
 	dataPtr = dataPtr[bytesRead:]
 
 	// destination
 	err = t.Destination.UnmarshalBinary(dataPtr[:ContractIDLen])
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal destination: %w", err)
+		return 0, xerrors.Errorf("failed to unmarshal destination: %w", err)
 	}
 	dataPtr = dataPtr[ContractIDLen:]
 
@@ -179,15 +264,16 @@ func (t *Transaction) UnmarshalBinary(data []byte) (err error) {
 	hasParameters, err := deserializeBoolean(dataPtr[0])
 	dataPtr = dataPtr[1:]
 	if err != nil {
-		return xerrors.Errorf("failed to deserialialize presence of field \"parameters\": %w", err)
+		return 0, xerrors.Errorf("failed to deserialialize presence of field \"parameters\": %w", err)
 	}
 	if hasParameters {
 		t.Parameters = &TransactionParameters{Value: &TransactionParametersValueRawBytes{}}
-		err = t.Parameters.UnmarshalBinary(dataPtr)
+		bytesRead, err = t.Parameters.unmarshalBinary(dataPtr)
 		if err != nil {
-			return xerrors.Errorf("failed to deserialize transaction parameters: %w", err)
+			return 0, xerrors.Errorf("failed to deserialize transaction parameters: %w", err)
 		}
+		dataPtr = dataPtr[bytesRead:]
 	}
 
-	return nil
+	return len(data) - len(dataPtr), nil
 }
@@ -2,10 +2,12 @@ package tezosprotocol_test
 
 import (
 	"encoding/hex"
+	"math"
 	"math/big"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/anchorageoss/tezosprotocol/v3/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,6 +27,7 @@ func TestEncodeTransaction(t *testing.T) {
 	encoded := hex.EncodeToString(encodedBytes)
 	expected := "6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78d0860301c8010080c2d72f0000e7670f32038107a59a2b9cfefae36ea21f5aa63c00"
 	require.Equal(expected, encoded)
+	testutil.AssertForgeMatchesGolden(t, "transaction", transaction)
 }
 
 func TestDecodeTransaction(t *testing.T) {
@@ -100,3 +103,137 @@ func TestDecodeTransactionWithParameters(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expectedParamsValue, observedParamsValue)
 }
+
+func TestTransactionAmountMutez(t *testing.T) {
+	require := require.New(t)
+	transaction := &tezosprotocol.Transaction{
+		Amount: big.NewInt(math.MaxInt64),
+	}
+	amount, err := transaction.AmountMutez()
+	require.NoError(err)
+	require.Equal(int64(math.MaxInt64), amount)
+	fee, err := transaction.FeeMutez()
+	require.NoError(err)
+	require.Equal(int64(0), fee)
+
+	transaction.Amount = new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1))
+	_, err = transaction.AmountMutez()
+	require.Error(err)
+	require.Contains(err.Error(), "overflows int64")
+}
+
+func TestNewTransfer(t *testing.T) {
+	require := require.New(t)
+	transaction := tezosprotocol.NewTransfer("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN", 100000000)
+	require.Equal(tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"), transaction.Source)
+	require.Equal(tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"), transaction.Destination)
+	require.Equal("100000000", transaction.Amount.String())
+	require.Equal("0", transaction.Fee.String())
+	require.Nil(transaction.Counter)
+	require.Equal(tezosprotocol.MinimumTransactionGasLimit, transaction.GasLimit.Int64())
+	require.Equal(tezosprotocol.NewAccountStorageLimitBytes, transaction.StorageLimit.Int64())
+	require.Nil(transaction.Parameters)
+}
+
+func TestNewContractCall(t *testing.T) {
+	require := require.New(t)
+	// the call forged in TestEncodeTransactionWithParameters
+	transaction, err := tezosprotocol.NewContractCall(
+		"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		"KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq",
+		nil,
+		"do",
+		&tezosprotocol.MichelineSeq{},
+	)
+	require.NoError(err)
+	require.Equal(tezosprotocol.HardGasLimitPerOperation, transaction.GasLimit.Int64())
+	require.Equal(tezosprotocol.HardStorageLimitPerOperation, transaction.StorageLimit.Int64())
+	transaction.Fee = big.NewInt(1266)
+	transaction.Counter = big.NewInt(1)
+	transaction.GasLimit = big.NewInt(10100)
+	transaction.StorageLimit = big.NewInt(277)
+	encodedBytes, err := transaction.MarshalBinary()
+	require.NoError(err)
+	expected := "6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78f20901f44e950200015ab81204ccd229281b9c462edaf0a43e78075f4600ff02000000050200000000"
+	require.Equal(expected, hex.EncodeToString(encodedBytes))
+
+	// other names make named entrypoints
+	transaction, err = tezosprotocol.NewContractCall(
+		"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		"KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq",
+		big.NewInt(5),
+		"%transfer",
+		michelineInt(1),
+	)
+	require.NoError(err)
+	require.Equal(tezosprotocol.EntrypointTagNamed, transaction.Parameters.Entrypoint.Tag())
+	name, err := transaction.Parameters.Entrypoint.Name()
+	require.NoError(err)
+	require.Equal("transfer", name)
+	require.Equal("5", transaction.Amount.String())
+
+	// staking pseudo-entrypoints have compact tags
+	transaction, err = tezosprotocol.NewContractCall(
+		"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		big.NewInt(1000000),
+		"stake",
+		&tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit},
+	)
+	require.NoError(err)
+	require.Equal(tezosprotocol.EntrypointStake, transaction.Parameters.Entrypoint)
+	entrypointBytes, err := transaction.Parameters.Entrypoint.MarshalBinary()
+	require.NoError(err)
+	require.Equal([]byte{6}, entrypointBytes)
+
+	_, err = tezosprotocol.NewContractCall("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq", nil, "", michelineInt(1))
+	require.Error(err)
+	_, err = tezosprotocol.NewContractCall("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq", nil, "do", nil)
+	require.Error(err)
+}
+
+func TestEncodeTransactionDefaultParameters(t *testing.T) {
+	require := require.New(t)
+	newTransaction := func(parameters *tezosprotocol.TransactionParameters) *tezosprotocol.Transaction {
+		return &tezosprotocol.Transaction{
+			Source:       tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+			Fee:          big.NewInt(50000),
+			Counter:      big.NewInt(1),
+			GasLimit:     big.NewInt(200),
+			StorageLimit: big.NewInt(0),
+			Amount:       big.NewInt(100000000),
+			Destination:  tezosprotocol.ContractID("tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN"),
+			Parameters:   parameters,
+		}
+	}
+	// same as TestEncodeTransaction: octez forges {"entrypoint": "default", "value":
+	// {"prim": "Unit"}} as if there were no parameters
+	withoutParameters := "6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78d0860301c8010080c2d72f0000e7670f32038107a59a2b9cfefae36ea21f5aa63c00"
+	rawUnit := tezosprotocol.TransactionParametersValueRawBytes{0x03, 0x0b}
+	for _, parameters := range []*tezosprotocol.TransactionParameters{
+		nil,
+		{Entrypoint: tezosprotocol.EntrypointDefault, Value: &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit}},
+		{Entrypoint: tezosprotocol.EntrypointDefault, Value: &rawUnit},
+	} {
+		encodedBytes, err := newTransaction(parameters).MarshalBinary()
+		require.NoError(err)
+		require.Equal(withoutParameters, hex.EncodeToString(encodedBytes))
+	}
+
+	// any other value passed to the default entrypoint is kept
+	encodedBytes, err := newTransaction(&tezosprotocol.TransactionParameters{
+		Entrypoint: tezosprotocol.EntrypointDefault,
+		Value:      michelineInt(1),
+	}).MarshalBinary()
+	require.NoError(err)
+	withParameters := "6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78d0860301c8010080c2d72f0000e7670f32038107a59a2b9cfefae36ea21f5aa63cff00000000020001"
+	require.Equal(withParameters, hex.EncodeToString(encodedBytes))
+
+	// as is Unit passed to any other entrypoint
+	encodedBytes, err = newTransaction(&tezosprotocol.TransactionParameters{
+		Entrypoint: tezosprotocol.EntrypointRoot,
+		Value:      &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimD_Unit},
+	}).MarshalBinary()
+	require.NoError(err)
+	require.Equal("ff0100000002030b", hex.EncodeToString(encodedBytes[len(encodedBytes)-8:]))
+}
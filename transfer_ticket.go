@@ -0,0 +1,246 @@
+package tezosprotocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/anchorageoss/tezosprotocol/v3/zarith"
+	"golang.org/x/xerrors"
+)
+
+// TransferTicket models the tezos transfer_ticket operation type, with which an
+// implicit account sends tickets it holds to a contract. The ticket is identified by
+// its contents, type and ticketer.
+type TransferTicket struct {
+	Source         ContractID
+	Fee            *big.Int
+	Counter        *big.Int
+	GasLimit       *big.Int
+	StorageLimit   *big.Int
+	TicketContents MichelineNode
+	TicketTy       MichelineNode
+	TicketTicketer ContractID
+	TicketAmount   *big.Int
+	Destination    ContractID
+	Entrypoint     Entrypoint
+}
+
+func (r *TransferTicket) String() string {
+	return fmt.Sprintf("%#v", r)
+}
+
+// GetTag implements OperationContents
+func (r *TransferTicket) GetTag() ContentsTag {
+	return ContentsTagTransferTicket
+}
+
+// GetSource returns the operation's source
+func (r *TransferTicket) GetSource() ContractID {
+	return r.Source
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (r *TransferTicket) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	// tag
+	buf.WriteByte(byte(r.GetTag()))
+
+	// source
+	sourceBytes, err := r.Source.EncodePubKeyHash()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write source: %w", err)
+	}
+	buf.Write(sourceBytes)
+
+	// fee
+	fee, err := zarith.Encode(r.Fee)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Fee: %w", err)
+	}
+	buf.Write(fee)
+
+	// counter
+	counter, err := zarith.Encode(r.Counter)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write Counter: %w", err)
+	}
+	buf.Write(counter)
+
+	// gas limit
+	gasLimit, err := zarith.Encode(r.GasLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write GasLimit: %w", err)
+	}
+	buf.Write(gasLimit)
+
+	// storage limit
+	storageLimit, err := zarith.Encode(r.StorageLimit)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write StorageLimit: %w", err)
+	}
+	buf.Write(storageLimit)
+
+	// ticket contents
+	ticketContentsBytes, err := marshalLengthPrefixedMicheline(r.TicketContents)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write ticket contents: %w", err)
+	}
+	buf.Write(ticketContentsBytes)
+
+	// ticket type
+	ticketTyBytes, err := marshalLengthPrefixedMicheline(r.TicketTy)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write ticket type: %w", err)
+	}
+	buf.Write(ticketTyBytes)
+
+	// ticket ticketer
+	ticketerBytes, err := r.TicketTicketer.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write ticket ticketer: %w", err)
+	}
+	buf.Write(ticketerBytes)
+
+	// ticket amount
+	ticketAmount, err := zarith.Encode(r.TicketAmount)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write TicketAmount: %w", err)
+	}
+	buf.Write(ticketAmount)
+
+	// destination
+	destinationBytes, err := r.Destination.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write destination: %w", err)
+	}
+	buf.Write(destinationBytes)
+
+	// entrypoint. Unlike in transaction parameters, it is always written as its
+	// name, with a 4-byte length prefix.
+	entrypointName, err := r.Entrypoint.Name()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write entrypoint: %w", err)
+	}
+	err = binary.Write(&buf, binary.BigEndian, uint32(len(entrypointName)))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to write entrypoint length: %w", err)
+	}
+	buf.WriteString(entrypointName)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *TransferTicket) UnmarshalBinary(data []byte) error {
+	_, err := r.unmarshalBinary(data)
+	return err
+}
+
+// unmarshalBinary decodes the transfer ticket at the start of data and returns the number
+// of bytes it occupies
+func (r *TransferTicket) unmarshalBinary(data []byte) (_ int, err error) {
+	// cleanly recover from out of bounds exceptions
+	defer func() {
+		if err == nil {
+			if r := recover(); r != nil {
+				err = catchOutOfRangeExceptions(r)
+			}
+		}
+	}()
+
+	dataPtr := data
+
+	// tag
+	tag := ContentsTag(dataPtr[0])
+	if tag != ContentsTagTransferTicket {
+		return 0, xerrors.Errorf("invalid tag for transfer ticket. Expected %d, saw %d", ContentsTagTransferTicket, tag)
+	}
+	dataPtr = dataPtr[1:]
+
+	// source
+	err = r.Source.UnmarshalBinary(dataPtr[:TaggedPubKeyHashLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal source: %w", err)
+	}
+	dataPtr = dataPtr[TaggedPubKeyHashLen:]
+
+	// fee
+	var bytesRead int
+	r.Fee, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal fee: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// counter
+	r.Counter, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal counter: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// gas limit
+	r.GasLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal gas limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// storage limit
+	r.StorageLimit, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal storage limit: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// ticket contents
+	r.TicketContents, bytesRead, err = unmarshalLengthPrefixedMicheline(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal ticket contents: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// ticket type
+	r.TicketTy, bytesRead, err = unmarshalLengthPrefixedMicheline(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal ticket type: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// ticket ticketer
+	err = r.TicketTicketer.UnmarshalBinary(dataPtr[:ContractIDLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal ticket ticketer: %w", err)
+	}
+	dataPtr = dataPtr[ContractIDLen:]
+
+	// ticket amount
+	r.TicketAmount, bytesRead, err = zarith.ReadNext(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal ticket amount: %w", err)
+	}
+	dataPtr = dataPtr[bytesRead:]
+
+	// destination
+	err = r.Destination.UnmarshalBinary(dataPtr[:ContractIDLen])
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal destination: %w", err)
+	}
+	dataPtr = dataPtr[ContractIDLen:]
+
+	// entrypoint
+	entrypointName, err := readLengthPrefixed(dataPtr)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal entrypoint: %w", err)
+	}
+	r.Entrypoint, err = entrypointFromName(string(entrypointName))
+	if err != nil {
+		return 0, xerrors.Errorf("failed to unmarshal entrypoint: %w", err)
+	}
+	dataPtr = dataPtr[4+len(entrypointName):]
+
+	return len(data) - len(dataPtr), nil
+}
@@ -0,0 +1,53 @@
+package tezosprotocol_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anchorageoss/tezosprotocol/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTicket(t *testing.T) {
+	require := require.New(t)
+	entrypoint, err := tezosprotocol.NewNamedEntrypoint("receive")
+	require.NoError(err)
+	transferTicket := &tezosprotocol.TransferTicket{
+		Source:         tezosprotocol.ContractID("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		Fee:            big.NewInt(1000),
+		Counter:        big.NewInt(2),
+		GasLimit:       big.NewInt(1500),
+		StorageLimit:   big.NewInt(100),
+		TicketContents: michelineString("hi"),
+		TicketTy:       &tezosprotocol.MichelinePrim{Prim: tezosprotocol.PrimT_string},
+		TicketTicketer: tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq"),
+		TicketAmount:   big.NewInt(5),
+		Destination:    tezosprotocol.ContractID("KT1GrStTuhgMMpzbNWKTt7NoXGrYiufrHDYq"),
+		Entrypoint:     entrypoint,
+	}
+	contractBytes, err := transferTicket.Destination.MarshalBinary()
+	require.NoError(err)
+	contractHex := hex.EncodeToString(contractBytes)
+
+	encodedBytes, err := transferTicket.MarshalBinary()
+	require.NoError(err)
+	expected := "9e0002298c03ed7d454a101eb7022bc95f7e5f41ac78e80702dc0b64" +
+		"00000007" + "01000000026869" + // contents
+		"00000002" + "0368" + // type
+		contractHex + "05" + contractHex + // ticketer, amount, destination
+		"00000007" + "72656365697665" // entrypoint
+	require.Equal(expected, hex.EncodeToString(encodedBytes))
+
+	decoded := &tezosprotocol.TransferTicket{}
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(transferTicket, decoded)
+
+	// preset entrypoints are also written by name
+	transferTicket.Entrypoint = tezosprotocol.EntrypointDefault
+	encodedBytes, err = transferTicket.MarshalBinary()
+	require.NoError(err)
+	require.Equal("0000000764656661756c74", hex.EncodeToString(encodedBytes[len(encodedBytes)-11:]))
+	require.NoError(decoded.UnmarshalBinary(encodedBytes))
+	require.Equal(transferTicket, decoded)
+}
@@ -2,6 +2,8 @@ package tezosprotocol_test
 
 import (
 	"encoding"
+	"errors"
+	"runtime"
 	"testing"
 
 	"github.com/anchorageoss/tezosprotocol/v3"
@@ -22,5 +24,7 @@ func TestUnmarshalingIndexOutOfBoundsException(t *testing.T) {
 		err := unmarshaler.UnmarshalBinary(emptyBytes)
 		require.Error(err, "%T", unmarshaler)
 		require.Contains(err.Error(), "out of bounds exception", "%T", unmarshaler)
+		var runtimeErr runtime.Error
+		require.True(errors.As(err, &runtimeErr), "%T", unmarshaler)
 	}
 }
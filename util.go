@@ -1,12 +1,17 @@
 package tezosprotocol
 
 import (
-	"fmt"
-	"strings"
+	"math/big"
+	"reflect"
+	"runtime"
 
 	"golang.org/x/xerrors"
 )
 
+// serializeBoolean encodes b the way the node does: 0xff for true and 0x00 for
+// false. Every presence flag for an optional field (e.g. a transaction's
+// parameters or an origination's delegate) must be written with this helper
+// rather than a raw 0/1 byte.
 func serializeBoolean(b bool) byte {
 	if b {
 		return byte(255)
@@ -14,6 +19,8 @@ func serializeBoolean(b bool) byte {
 	return byte(0)
 }
 
+// deserializeBoolean is the inverse of serializeBoolean. Any byte other than
+// 0x00 or 0xff is rejected.
 func deserializeBoolean(b byte) (bool, error) {
 	switch b {
 	case 0:
@@ -25,9 +32,54 @@ func deserializeBoolean(b byte) (bool, error) {
 	}
 }
 
+// mutezToInt64 converts an amount in mutez to int64. A nil amount is treated as
+// zero. It errors if the amount is negative or does not fit in an int64.
+func mutezToInt64(field string, amount *big.Int) (int64, error) {
+	if amount == nil {
+		return 0, nil
+	}
+	if amount.Sign() < 0 {
+		return 0, xerrors.Errorf("%s %s is negative", field, amount)
+	}
+	if !amount.IsInt64() {
+		return 0, xerrors.Errorf("%s %s overflows int64", field, amount)
+	}
+	return amount.Int64(), nil
+}
+
+// catchOutOfRangeExceptions turns a recovered index or slice bounds panic, as caused
+// by parsing truncated input, into an error. Any other panic is re-raised.
 func catchOutOfRangeExceptions(r interface{}) error {
-	if strings.Contains(fmt.Sprintf("%s", r), "out of range") {
-		return xerrors.New("out of bounds exception while parsing operation")
+	if runtimeErr, ok := r.(runtime.Error); ok && isBoundsError(runtimeErr) {
+		return outOfBoundsError{cause: runtimeErr}
 	}
 	panic(r)
 }
+
+// outOfBoundsError is ErrOutOfBounds caused by a runtime bounds error. It unwraps to
+// the runtime error.
+type outOfBoundsError struct {
+	cause runtime.Error
+}
+
+func (e outOfBoundsError) Error() string {
+	return ErrOutOfBounds.Error() + ": " + e.cause.Error()
+}
+
+func (e outOfBoundsError) Unwrap() error {
+	return e.cause
+}
+
+// Is makes errors.Is(err, ErrOutOfBounds) hold
+func (e outOfBoundsError) Is(target error) bool {
+	return target == ErrOutOfBounds
+}
+
+// isBoundsError reports whether err is an index or slice bounds failure. The runtime
+// doesn't export its bounds error type, so it is identified by name rather than by
+// its message, which varies between Go versions. Other runtime errors, such as a nil
+// map write, are bugs rather than malformed input and must not match;
+// TestCatchOutOfRangeExceptions pins both against the Go version in use.
+func isBoundsError(err runtime.Error) bool {
+	return reflect.TypeOf(err).String() == "runtime.boundsError"
+}
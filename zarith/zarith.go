@@ -1,9 +1,8 @@
 package zarith
 
 import (
-	"bytes"
 	"encoding/hex"
-	"fmt"
+	"io"
 	"math/big"
 
 	"golang.org/x/xerrors"
@@ -17,45 +16,28 @@ const lengthZarithBitSegment = 7
 // and the second-from-the-left bit is the sign flag
 const lengthZarithBitSegmentWithSignFlag = lengthZarithBitSegment - 1
 
+const (
+	continuationBitMask byte = 0x80
+	signFlagMask        byte = 0x40
+)
+
 // Decode decodes a zarith encoded unsigned integer from the entire input byte array.
 // Assumes the input contains no extra trailing bytes.
 func Decode(source []byte) (*big.Int, error) {
-	if len(source) == 0 {
-		return nil, xerrors.New("expected non-empty byte array")
-	}
-
-	// Split input into 8-bit bitstrings
-	segments := make([]string, len(source))
-	for i, curByte := range source {
-		segments[i] = fmt.Sprintf("%08b", curByte)
-	}
-
-	// Trim off leading continuation bit from each segment
-	for i, segment := range segments {
-		segments[i] = segment[1:]
-	}
-
-	// Reverse the order of the segments.
-	// Source: https://github.com/golang/go/wiki/SliceTricks#reversing
-	for i := len(segments)/2 - 1; i >= 0; i-- {
-		opp := len(segments) - 1 - i
-		segments[i], segments[opp] = segments[opp], segments[i]
-	}
-
-	// Concat all the bits
-	bitStringBuf := bytes.Buffer{}
-	for _, segment := range segments {
-		bitStringBuf.WriteString(segment)
+	if err := validateContinuationBits(source); err != nil {
+		return nil, err
 	}
-	bitString := bitStringBuf.String()
+	return decodeMagnitude(source, lengthZarithBitSegment), nil
+}
 
-	// Convert from base 2 to base 10
-	ret := new(big.Int)
-	_, success := ret.SetString(bitString, 2)
-	if !success {
-		return nil, xerrors.Errorf("failed to parse bit string %s to big.Int", bitString)
+// DecodeStrict is like Decode, but also errors if the input is not the minimal
+// encoding of its value, i.e. if it ends in a zero segment. Re-encoding a strictly
+// decoded value always gives back the input bytes.
+func DecodeStrict(source []byte) (*big.Int, error) {
+	if len(source) > 1 && source[len(source)-1] == 0 {
+		return nil, xerrors.New("non-minimal zarith encoding: trailing zero segment")
 	}
-	return ret, nil
+	return Decode(source)
 }
 
 // DecodeHex decodes a zarith encoded unsigned integer from the entire input hex string.
@@ -75,7 +57,7 @@ func DecodeHex(source string) (*big.Int, error) {
 func ReadNext(byteStream []byte) (*big.Int, int, error) {
 	for n := 0; n < len(byteStream); n++ {
 		// if leftmost bit is zero
-		if byteStream[n]&byte(128) == 0 {
+		if byteStream[n]&continuationBitMask == 0 {
 			number, err := Decode(byteStream[:n+1])
 			return number, n + 1, err
 		}
@@ -83,6 +65,38 @@ func ReadNext(byteStream []byte) (*big.Int, int, error) {
 	return nil, -1, xerrors.New("exhausted input while searching for end of next zarith number")
 }
 
+// ReadNextFrom reads the next variable-length zarith-encoded unsigned integer from
+// r, one byte at a time, stopping after the byte that ends the number. Returns the
+// zarith number and the count of bytes read. It returns io.EOF if r is exhausted
+// before any byte is read, and io.ErrUnexpectedEOF if it is exhausted mid-number.
+func ReadNextFrom(r io.ByteReader) (*big.Int, int, error) {
+	encoded, err := readNextEncoded(r)
+	if err != nil {
+		return nil, len(encoded), err
+	}
+	number, err := Decode(encoded)
+	return number, len(encoded), err
+}
+
+// readNextEncoded reads the bytes of the next zarith number from r
+func readNextEncoded(r io.ByteReader) ([]byte, error) {
+	var encoded []byte
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF && len(encoded) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return encoded, err
+		}
+		encoded = append(encoded, b)
+		// if leftmost bit is zero
+		if b&continuationBitMask == 0 {
+			return encoded, nil
+		}
+	}
+}
+
 // Encode encodes an unsigned integer to zarith
 func Encode(value *big.Int) ([]byte, error) {
 	if value == nil {
@@ -91,54 +105,7 @@ func Encode(value *big.Int) ([]byte, error) {
 	if value.Sign() == -1 {
 		return nil, xerrors.Errorf("cannot encode negative integer: %s", value)
 	}
-
-	// Convert to base 2 representation
-	valueBitstring := value.Text(2)
-
-	// Pad with leading zeros until number of bits is a multiple of 7
-	var numPaddingBitsRequired int
-	if len(valueBitstring)%lengthZarithBitSegment == 0 {
-		numPaddingBitsRequired = 0
-	} else {
-		numPaddingBitsRequired = lengthZarithBitSegment - (len(valueBitstring) % lengthZarithBitSegment)
-	}
-	paddedBitstringBuffer := bytes.Buffer{}
-	for i := 0; i < numPaddingBitsRequired; i++ {
-		paddedBitstringBuffer.WriteString("0")
-	}
-	paddedBitstringBuffer.WriteString(valueBitstring)
-	paddedBitString := paddedBitstringBuffer.String()
-
-	// Split into 7-bit segments
-	numSegments := len(paddedBitString) / lengthZarithBitSegment
-	segments := make([]string, numSegments)
-	for i := 0; i < numSegments; i++ {
-		offset := lengthZarithBitSegment * i
-		segments[i] = paddedBitString[offset : offset+lengthZarithBitSegment]
-	}
-
-	// Reverse the order of the segments
-	// Source: https://github.com/golang/go/wiki/SliceTricks#reversing
-	for i := len(segments)/2 - 1; i >= 0; i-- {
-		opp := len(segments) - 1 - i
-		segments[i], segments[opp] = segments[opp], segments[i]
-	}
-
-	// Prepend a 1 bit to each segment but the last, and a 0 bit to the last
-	for i := 0; i < len(segments)-1; i++ {
-		segments[i] = "1" + segments[i]
-	}
-	segments[len(segments)-1] = "0" + segments[len(segments)-1]
-
-	// Concat segments to form the output bitstring
-	outputBitStringBuf := bytes.Buffer{}
-	for _, segment := range segments {
-		outputBitStringBuf.WriteString(segment)
-	}
-	outputBitString := outputBitStringBuf.String()
-
-	// Convert from bitstring to byte array
-	return bitStringToBytes(outputBitString), nil
+	return encodeMagnitude(value.Bytes(), lengthZarithBitSegment, 0), nil
 }
 
 // EncodeToHex encodes an unsigned integer to zarith
@@ -155,66 +122,12 @@ func EncodeSigned(value *big.Int) []byte {
 	if value == nil || value.Sign() == 0 {
 		return []byte{0}
 	}
-	isNegative := value.Sign() == -1
-	signBit := "0"
-	if isNegative {
-		signBit = "1"
-	}
-
-	// Convert to base 2 representation
-	valueBitstring := big.NewInt(0).Abs(value).Text(2)
-	numValueBits := len(valueBitstring)
-
-	encodingFitsInOneByte := numValueBits <= lengthZarithBitSegmentWithSignFlag
-
-	// Pad with leading zeros until number of bits is a multiple of 7
-	var numPaddingBitsRequired int
-	if encodingFitsInOneByte {
-		numPaddingBitsRequired = lengthZarithBitSegmentWithSignFlag - numValueBits
-	} else {
-		numBitsAfterFirstSegment := numValueBits - lengthZarithBitSegmentWithSignFlag
-		if numBitsAfterFirstSegment%lengthZarithBitSegment == 0 {
-			numPaddingBitsRequired = 0
-		} else {
-			numPaddingBitsRequired = lengthZarithBitSegment - (numBitsAfterFirstSegment % lengthZarithBitSegment)
-		}
-	}
-	paddedBitStringBuffer := bytes.Buffer{}
-	for i := 0; i < numPaddingBitsRequired; i++ {
-		paddedBitStringBuffer.WriteString("0")
-	}
-	paddedBitStringBuffer.WriteString(valueBitstring)
-	paddedBitString := paddedBitStringBuffer.String()
-
-	// First segment is the rightmost 6 bits of the input value, prefixed with the sign bit
-	segments := make([]string, 0)
-	firstSegment := paddedBitString[len(paddedBitString)-lengthZarithBitSegmentWithSignFlag:]
-	firstSegment = signBit + firstSegment
-	segments = append(segments, firstSegment)
-	paddedBitString = paddedBitString[:len(paddedBitString)-lengthZarithBitSegmentWithSignFlag] // pop 6 bits from the right
-
-	// Remaining 7-bit segments collected from right to left
-	numSevenBitSegments := len(paddedBitString) / 7
-	for i := 0; i < numSevenBitSegments; i++ {
-		segments = append(segments, paddedBitString[len(paddedBitString)-lengthZarithBitSegment:])
-		paddedBitString = paddedBitString[:len(paddedBitString)-lengthZarithBitSegment] // pop 7 bits from the right
-	}
-
-	// Prepend a 1 bit to each segment but the last, and a 0 bit to the last
-	for i := 0; i < len(segments)-1; i++ {
-		segments[i] = "1" + segments[i]
-	}
-	segments[len(segments)-1] = "0" + segments[len(segments)-1]
-
-	// Concat segments to form the output bitstring
-	encodedBitStringBuf := bytes.Buffer{}
-	for _, segment := range segments {
-		encodedBitStringBuf.WriteString(segment)
+	var signFlag byte
+	if value.Sign() == -1 {
+		signFlag = signFlagMask
 	}
-	encodedBitString := encodedBitStringBuf.String()
-
-	// Convert from bitstring to byte array
-	return bitStringToBytes(encodedBitString)
+	// big.Int.Bytes returns the absolute value
+	return encodeMagnitude(value.Bytes(), lengthZarithBitSegmentWithSignFlag, signFlag)
 }
 
 // EncodeSignedToHex encodes a signed integer to zarith
@@ -226,52 +139,28 @@ func EncodeSignedToHex(value *big.Int) string {
 // DecodeSigned decodes a zarith encoded signed integer from the entire input byte array.
 // Assumes the input contains no extra trailing bytes.
 func DecodeSigned(source []byte) (*big.Int, error) {
-	if len(source) == 0 {
-		return nil, xerrors.New("expected non-empty byte array")
-	}
-
-	// Split input into 8-bit bitstrings
-	segments := make([]string, len(source))
-	for i, curByte := range source {
-		segments[i] = fmt.Sprintf("%08b", curByte)
-	}
-
-	// Trim off leading continuation bit from each segment
-	for i, segment := range segments {
-		segments[i] = segment[1:]
-	}
-
-	// Trim off the sign flag from the first segment
-	firstSegment := []rune(segments[0])
-	isNegative := firstSegment[0] == '1'
-	segments[0] = string(firstSegment[1:])
-
-	// Reverse the order of the segments.
-	// Source: https://github.com/golang/go/wiki/SliceTricks#reversing
-	for i := len(segments)/2 - 1; i >= 0; i-- {
-		opp := len(segments) - 1 - i
-		segments[i], segments[opp] = segments[opp], segments[i]
+	if err := validateContinuationBits(source); err != nil {
+		return nil, err
 	}
-
-	// Concat all the bits
-	bitStringBuf := bytes.Buffer{}
-	for _, segment := range segments {
-		bitStringBuf.WriteString(segment)
+	ret := decodeMagnitude(source, lengthZarithBitSegmentWithSignFlag)
+	if source[0]&signFlagMask != 0 {
+		ret.Neg(ret)
 	}
-	bitString := bitStringBuf.String()
+	return ret, nil
+}
 
-	// Add sign flag
-	if isNegative {
-		bitString = "-" + bitString
+// DecodeSignedStrict is like DecodeSigned, but also errors if the input is not the
+// minimal encoding of its value: if it ends in a zero segment, or encodes
+// negative zero. Re-encoding a strictly decoded value always gives back the input
+// bytes.
+func DecodeSignedStrict(source []byte) (*big.Int, error) {
+	if len(source) > 1 && source[len(source)-1] == 0 {
+		return nil, xerrors.New("non-minimal zarith encoding: trailing zero segment")
 	}
-
-	// Convert from base 2 to base 10
-	ret := new(big.Int)
-	_, success := ret.SetString(bitString, 2)
-	if !success {
-		return nil, xerrors.Errorf("failed to parse bit string %s to big.Int", bitString)
+	if len(source) == 1 && source[0] == 0x40 {
+		return nil, xerrors.New("non-minimal zarith encoding: negative zero")
 	}
-	return ret, nil
+	return DecodeSigned(source)
 }
 
 // DecodeSignedHex decodes a zarith encoded signed integer from the entire input hex string.
@@ -291,7 +180,7 @@ func DecodeSignedHex(source string) (*big.Int, error) {
 func ReadNextSigned(byteStream []byte) (*big.Int, int, error) {
 	for n := 0; n < len(byteStream); n++ {
 		// if leftmost bit is zero
-		if byteStream[n]&byte(128) == 0 {
+		if byteStream[n]&continuationBitMask == 0 {
 			number, err := DecodeSigned(byteStream[:n+1])
 			return number, n + 1, err
 		}
@@ -299,14 +188,95 @@ func ReadNextSigned(byteStream []byte) (*big.Int, int, error) {
 	return nil, -1, xerrors.New("exhausted input while searching for end of next zarith number")
 }
 
-func bitStringToBytes(bitstring string) []byte {
-	bytes := make([]byte, len(bitstring)/8)
-	for i := 0; i < len(bitstring); i++ {
-		bit := bitstring[i]
-		if bit < '0' || bit > '1' {
-			panic(xerrors.Errorf("%c is not a bit value", bit))
+// ReadNextSignedFrom reads the next variable-length zarith-encoded signed integer
+// from r, one byte at a time, stopping after the byte that ends the number.
+// Returns the zarith number and the count of bytes read. It returns io.EOF if r is
+// exhausted before any byte is read, and io.ErrUnexpectedEOF if it is exhausted
+// mid-number.
+func ReadNextSignedFrom(r io.ByteReader) (*big.Int, int, error) {
+	encoded, err := readNextEncoded(r)
+	if err != nil {
+		return nil, len(encoded), err
+	}
+	number, err := DecodeSigned(encoded)
+	return number, len(encoded), err
+}
+
+// validateContinuationBits checks that source is exactly one zarith number: every
+// byte but the last has its continuation bit set, and the last has it clear
+func validateContinuationBits(source []byte) error {
+	if len(source) == 0 {
+		return xerrors.New("expected non-empty byte array")
+	}
+	for i, curByte := range source[:len(source)-1] {
+		if curByte&continuationBitMask == 0 {
+			return xerrors.Errorf("malformed zarith number: byte %d of %d ends the number early", i+1, len(source))
+		}
+	}
+	if source[len(source)-1]&continuationBitMask != 0 {
+		return xerrors.New("malformed zarith number: last byte has its continuation bit set")
+	}
+	return nil
+}
+
+// encodeMagnitude encodes the big-endian unsigned integer magnitude as zarith
+// segments, least significant first. The first segment holds firstSegmentLen bits
+// of the value, along with flags, and the others hold 7 bits. Every segment but the
+// last has its continuation bit set.
+func encodeMagnitude(magnitude []byte, firstSegmentLen uint, flags byte) []byte {
+	encoded := make([]byte, 0, len(magnitude)*8/lengthZarithBitSegment+2)
+	segmentLen := firstSegmentLen
+	var bits uint64 // bits of magnitude not yet encoded, least significant first
+	var numBits uint
+	next := len(magnitude) - 1
+	for {
+		for numBits < segmentLen && next >= 0 {
+			bits |= uint64(magnitude[next]) << numBits
+			numBits += 8
+			next--
+		}
+		segment := byte(bits&(1<<segmentLen-1)) | flags
+		bits >>= segmentLen
+		if numBits > segmentLen {
+			numBits -= segmentLen
+		} else {
+			numBits = 0
+		}
+		if bits == 0 && next < 0 {
+			return append(encoded, segment)
 		}
-		bytes[i>>3] |= (bit - '0') << uint(7-i&7)
+		encoded = append(encoded, segment|continuationBitMask)
+		segmentLen, flags = lengthZarithBitSegment, 0
+	}
+}
+
+// decodeMagnitude decodes the value bits of the zarith segments in source. The
+// first segment holds firstSegmentLen bits of the value and the others hold 7.
+func decodeMagnitude(source []byte, firstSegmentLen uint) *big.Int {
+	magnitude := make([]byte, (len(source)*lengthZarithBitSegment+7)/8)
+	next := len(magnitude) // magnitude is filled from its least significant byte
+	segmentLen := firstSegmentLen
+	var bits uint64 // decoded bits not yet written to magnitude
+	var numBits uint
+	for _, segment := range source {
+		bits |= uint64(segment&(1<<segmentLen-1)) << numBits
+		numBits += segmentLen
+		for numBits >= 8 {
+			next--
+			magnitude[next] = byte(bits)
+			bits >>= 8
+			numBits -= 8
+		}
+		segmentLen = lengthZarithBitSegment
+	}
+	if numBits > 0 {
+		next--
+		magnitude[next] = byte(bits)
+	}
+	ret := new(big.Int).SetBytes(magnitude[next:])
+	if ret.Sign() == 0 {
+		// keep zero values identical to big.NewInt(0)
+		return new(big.Int)
 	}
-	return bytes
+	return ret
 }
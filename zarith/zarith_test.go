@@ -3,6 +3,7 @@ package zarith_test
 import (
 	"bytes"
 	"encoding/hex"
+	"io"
 	"math/big"
 	"testing"
 
@@ -195,9 +196,121 @@ func TestReadNextSigned(t *testing.T) {
 	require.Equal("-120053", decoded.String())
 }
 
+func TestReadNextFrom(t *testing.T) {
+	require := require.New(t)
+	// 50000 || 5 || -120053 || truncated number
+	reader := bytes.NewReader(fromHexString(t, "d0860305f5d30e80"))
+
+	decoded, bytesRead, err := zarith.ReadNextFrom(reader)
+	require.NoError(err)
+	require.Equal(3, bytesRead)
+	require.Equal("50000", decoded.String())
+
+	decoded, bytesRead, err = zarith.ReadNextFrom(reader)
+	require.NoError(err)
+	require.Equal(1, bytesRead)
+	require.Equal("5", decoded.String())
+
+	decoded, bytesRead, err = zarith.ReadNextSignedFrom(reader)
+	require.NoError(err)
+	require.Equal(3, bytesRead)
+	require.Equal("-120053", decoded.String())
+
+	_, bytesRead, err = zarith.ReadNextFrom(reader)
+	require.Equal(io.ErrUnexpectedEOF, err)
+	require.Equal(1, bytesRead)
+
+	_, bytesRead, err = zarith.ReadNextFrom(reader)
+	require.Equal(io.EOF, err)
+	require.Equal(0, bytesRead)
+}
+
+func fromHexString(t *testing.T, s string) []byte {
+	decoded, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	require := require.New(t)
+	for _, input := range []string{"80", "ff", "8080", "0101", "d00603"} {
+		_, err := zarith.DecodeHex(input)
+		require.Error(err, input)
+		_, err = zarith.DecodeSignedHex(input)
+		require.Error(err, input)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	require := require.New(t)
+	for _, input := range []string{"00", "01", "d08603", "80c2d72f"} {
+		decoded, err := zarith.DecodeStrict(fromHexString(t, input))
+		require.NoError(err, input)
+		reencoded, err := zarith.EncodeToHex(decoded)
+		require.NoError(err)
+		require.Equal(input, reencoded)
+	}
+
+	// 50000 and 0 with a superfluous zero segment
+	for _, input := range []string{"d0868300", "8000"} {
+		_, err := zarith.DecodeHex(input)
+		require.NoError(err, input)
+		_, err = zarith.DecodeStrict(fromHexString(t, input))
+		require.Error(err, input)
+	}
+
+	// signed
+	decoded, err := zarith.DecodeSignedStrict(fromHexString(t, "f5d30e"))
+	require.NoError(err)
+	require.Equal("-120053", decoded.String())
+	for _, input := range []string{"f5d38e00", "8000", "40"} {
+		_, err := zarith.DecodeSignedStrict(fromHexString(t, input))
+		require.Error(err, input)
+	}
+}
+
 func TestNegativeInputForUnsignedZarithValue(t *testing.T) {
 	require := require.New(t)
 	input := big.NewInt(-10)
 	_, err := zarith.Encode(input)
 	require.Error(err)
 }
+
+func BenchmarkEncode(b *testing.B) {
+	value, _ := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	for i := 0; i < b.N; i++ {
+		if _, err := zarith.Encode(value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	value, _ := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	encoded, err := zarith.Encode(value)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := zarith.Decode(encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeSigned(b *testing.B) {
+	value, _ := new(big.Int).SetString("-1000000000000000000000000000000", 10)
+	for i := 0; i < b.N; i++ {
+		zarith.EncodeSigned(value)
+	}
+}
+
+func BenchmarkDecodeSigned(b *testing.B) {
+	value, _ := new(big.Int).SetString("-1000000000000000000000000000000", 10)
+	encoded := zarith.EncodeSigned(value)
+	for i := 0; i < b.N; i++ {
+		if _, err := zarith.DecodeSigned(encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}